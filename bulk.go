@@ -0,0 +1,232 @@
+package todoist
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+)
+
+const (
+	defaultBulkConcurrency = 4
+	// bulkRateLimitRetries bounds how often an item is retried after the
+	// server answered with a RateLimitedError.
+	bulkRateLimitRetries = 3
+)
+
+type BulkOptions struct {
+	Concurrency int                // Optional, defaults to 4, unused by BulkClose, BulkReopen and BulkDelete
+	Progress    func(BulkProgress) // Optional, called once per finished item
+}
+
+// BulkProgress reports a finished item of a bulk operation. Progress callbacks
+// are never called concurrently.
+type BulkProgress struct {
+	Key    string
+	Err    error
+	Done   int
+	Failed int
+	Total  int
+}
+
+// BulkResult is the outcome of a single item of a bulk operation. Task is set
+// by BulkUpdate and BulkAdd on success.
+type BulkResult struct {
+	Task *Task
+	Err  error
+}
+
+func (api *Client) BulkClose(ids []string, options BulkOptions) map[string]BulkResult {
+	return api.BulkCloseContext(ids, options, context.Background())
+}
+func (api *Client) BulkReopen(ids []string, options BulkOptions) map[string]BulkResult {
+	return api.BulkReopenContext(ids, options, context.Background())
+}
+func (api *Client) BulkDelete(ids []string, options BulkOptions) map[string]BulkResult {
+	return api.BulkDeleteContext(ids, options, context.Background())
+}
+func (api *Client) BulkUpdate(updates map[string]UpdateTaskRequest, options BulkOptions) map[string]BulkResult {
+	return api.BulkUpdateContext(updates, options, context.Background())
+}
+func (api *Client) BulkAdd(requests []AddTaskRequest, options BulkOptions) map[int]BulkResult {
+	return api.BulkAddContext(requests, options, context.Background())
+}
+
+// BulkCloseContext closes the tasks with Sync API item_close commands, up to
+// 100 of them in a single request.
+func (api *Client) BulkCloseContext(ids []string, options BulkOptions, context context.Context) map[string]BulkResult {
+	return api.runBulkCommands(context, "item_close", ids, options)
+}
+
+// BulkReopenContext reopens the tasks with Sync API item_uncomplete commands,
+// up to 100 of them in a single request.
+func (api *Client) BulkReopenContext(ids []string, options BulkOptions, context context.Context) map[string]BulkResult {
+	return api.runBulkCommands(context, "item_uncomplete", ids, options)
+}
+
+// BulkDeleteContext deletes the tasks with Sync API item_delete commands, up
+// to 100 of them in a single request.
+func (api *Client) BulkDeleteContext(ids []string, options BulkOptions, context context.Context) map[string]BulkResult {
+	return api.runBulkCommands(context, "item_delete", ids, options)
+}
+func (api *Client) BulkUpdateContext(updates map[string]UpdateTaskRequest, options BulkOptions, context context.Context) map[string]BulkResult {
+	ids := make([]string, 0, len(updates))
+	for id := range updates {
+		ids = append(ids, id)
+	}
+	return runBulk(context, api, ids, options, api.bulkUpdateTask(updates))
+}
+
+// BulkAddContext adds the tasks concurrently. Results are keyed by the index
+// of the request in requests.
+func (api *Client) BulkAddContext(requests []AddTaskRequest, options BulkOptions, context context.Context) map[int]BulkResult {
+	indexes := make([]int, len(requests))
+	for i := range requests {
+		indexes[i] = i
+	}
+	return runBulk(context, api, indexes, options, api.bulkAddTask(requests))
+}
+
+func (api *Client) bulkUpdateTask(updates map[string]UpdateTaskRequest) func(context.Context, string) (*Task, error) {
+	return func(ctx context.Context, id string) (*Task, error) {
+		return api.UpdateTaskContext(id, updates[id], ctx)
+	}
+}
+func (api *Client) bulkAddTask(requests []AddTaskRequest) func(context.Context, int) (*Task, error) {
+	return func(ctx context.Context, i int) (*Task, error) {
+		return api.AddTaskContext(requests[i], ctx)
+	}
+}
+
+// runBulk applies fn to every key on a bounded pool of goroutines. Every call
+// waits for a rate limiter shared by the client, and a RateLimitedError pauses
+// all workers for its RetryAfter before the item is retried. Failures are
+// reported per key instead of stopping the remaining items.
+func runBulk[K comparable](ctx context.Context, api *Client, keys []K, options BulkOptions, fn func(context.Context, K) (*Task, error)) map[K]BulkResult {
+	concurrency := options.Concurrency
+	if concurrency <= 0 {
+		concurrency = defaultBulkConcurrency
+	}
+
+	var mu sync.Mutex
+	results := make(map[K]BulkResult, len(keys))
+	progress := BulkProgress{Total: len(keys)}
+
+	queue := make(chan K)
+	var wg sync.WaitGroup
+	for w := 0; w < concurrency; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for key := range queue {
+				task, err := runBulkItem(ctx, api, key, fn)
+
+				mu.Lock()
+				results[key] = BulkResult{Task: task, Err: err}
+				progress.Done++
+				if err != nil {
+					progress.Failed++
+				}
+				if options.Progress != nil {
+					current := progress
+					current.Key = fmt.Sprint(key)
+					current.Err = err
+					options.Progress(current)
+				}
+				mu.Unlock()
+			}
+		}()
+	}
+
+	for _, key := range keys {
+		queue <- key
+	}
+	close(queue)
+	wg.Wait()
+
+	return results
+}
+
+// runBulkCommands sends a Sync command of commandType for every task id, in
+// requests of up to syncCommandsLimit commands sent one after the other. Like
+// the items of runBulk, every request waits for the rate limiter and is
+// retried after a RateLimitedError. A failed request fails all ids of its
+// batch, otherwise every id gets the status of its own command.
+func (api *Client) runBulkCommands(ctx context.Context, commandType string, ids []string, options BulkOptions) map[string]BulkResult {
+	results := make(map[string]BulkResult, len(ids))
+	progress := BulkProgress{Total: len(ids)}
+
+	for start := 0; start < len(ids); start += syncCommandsLimit {
+		end := start + syncCommandsLimit
+		if end > len(ids) {
+			end = len(ids)
+		}
+		commands := make([]SyncCommand, 0, end-start)
+		for _, id := range ids[start:end] {
+			commands = append(commands, newSyncCommand(commandType, map[string]string{
+				"id": id,
+			}))
+		}
+
+		var response *SyncResponse
+		_, err := runBulkItem(ctx, api, ids[start], func(ctx context.Context, _ string) (*Task, error) {
+			var err error
+			response, err = api.executeCommands(ctx, commands...)
+			return nil, err
+		})
+
+		for i, id := range ids[start:end] {
+			if response != nil {
+				err = response.CommandError(commands[i])
+			}
+			results[id] = BulkResult{Err: err}
+			progress.Done++
+			if err != nil {
+				progress.Failed++
+			}
+			if options.Progress != nil {
+				current := progress
+				current.Key = id
+				current.Err = err
+				options.Progress(current)
+			}
+		}
+	}
+
+	return results
+}
+
+func runBulkItem[K comparable](ctx context.Context, api *Client, key K, fn func(context.Context, K) (*Task, error)) (*Task, error) {
+	limiter := api.bulkRateLimiter()
+	for attempt := 0; ; attempt++ {
+		if limiter != nil {
+			if err := limiter.Wait(ctx); err != nil {
+				return nil, err
+			}
+		}
+		task, err := fn(withRetry(ctx, attempt), key)
+
+		var rateLimited *RateLimitedError
+		if errors.As(err, &rateLimited) && attempt < bulkRateLimitRetries {
+			if limiter != nil {
+				limiter.backoff(rateLimited.RetryAfter)
+			}
+			api.logRetry(ctx, fmt.Sprint(key), attempt+1, rateLimited.RetryAfter)
+			continue
+		}
+		return task, err
+	}
+}
+
+// bulkRateLimiter returns the limiter bulk operations wait for themselves.
+// With OptionRateLimit every request already waits for the client's limiter,
+// otherwise bulk operations share a limiter set to DefaultRateLimit.
+func (api *Client) bulkRateLimiter() *rateLimiter {
+	if api.limiter != nil {
+		return nil
+	}
+	api.bulkOnce.Do(func() {
+		api.bulkLimiter = newRateLimiter(DefaultRateLimit.Requests, DefaultRateLimit.Period, DefaultRateLimit.Burst)
+	})
+	return api.bulkLimiter
+}
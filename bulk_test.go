@@ -0,0 +1,98 @@
+package todoist
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+	"testing"
+	"time"
+)
+
+func TestBulkClose(t *testing.T) {
+	http.DefaultServeMux = new(http.ServeMux)
+	requests := 0
+	var commands []string
+	http.HandleFunc("/sync", func(rw http.ResponseWriter, r *http.Request) {
+		requests++
+		if requests == 1 {
+			rw.Header().Set("Retry-After", "0")
+			rw.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		request := testSyncRequest{}
+		_ = json.NewDecoder(r.Body).Decode(&request)
+		status := map[string]interface{}{}
+		for _, command := range request.Commands {
+			commands = append(commands, command.Type+" "+string(command.Args))
+			status[command.UUID] = "ok"
+			if string(command.Args) == `{"id":"3"}` {
+				status[command.UUID] = map[string]interface{}{"error_code": 20, "error": "Item not found", "error_tag": "ITEM_NOT_FOUND"}
+			}
+		}
+		_ = json.NewEncoder(rw).Encode(map[string]interface{}{"sync_status": status})
+	})
+	once.Do(startServer)
+
+	ids := make([]string, 150)
+	for i := range ids {
+		ids[i] = strconv.Itoa(i + 1)
+	}
+	api := New(validToken,
+		OptionSyncAPIURL("http://"+serverAddr+"/"),
+		OptionRateLimit(RateLimit{Requests: 1000, Period: time.Second, Burst: 10}))
+	var progress []BulkProgress
+	results := api.BulkClose(ids, BulkOptions{
+		Progress: func(p BulkProgress) {
+			progress = append(progress, p)
+		},
+	})
+
+	if requests != 3 || len(commands) != 150 || commands[0] != `item_close {"id":"1"}` || commands[149] != `item_close {"id":"150"}` {
+		t.Fatalf("Unexpected requests %d with commands %v", requests, commands)
+	}
+	syncError := SyncError{}
+	if len(results) != 150 || results["1"].Err != nil || results["150"].Err != nil ||
+		!errors.As(results["3"].Err, &syncError) || syncError.ErrorTag != "ITEM_NOT_FOUND" {
+		t.Fatalf("Unexpected results: %+v", results)
+	}
+	last := progress[len(progress)-1]
+	if len(progress) != 150 || last.Done != 150 || last.Failed != 1 || last.Total != 150 {
+		t.Fatalf("Unexpected progress: %+v", last)
+	}
+}
+
+func TestBulkDeleteRequestError(t *testing.T) {
+	http.DefaultServeMux = new(http.ServeMux)
+	http.HandleFunc("/sync", func(rw http.ResponseWriter, r *http.Request) {
+		rw.WriteHeader(http.StatusInternalServerError)
+	})
+	once.Do(startServer)
+
+	api := New(validToken, OptionSyncAPIURL("http://"+serverAddr+"/"))
+	results := api.BulkDelete([]string{"1", "2"}, BulkOptions{})
+	statusCodeError := StatusCodeError{}
+	for _, id := range []string{"1", "2"} {
+		if !errors.As(results[id].Err, &statusCodeError) || statusCodeError.Code != http.StatusInternalServerError {
+			t.Fatalf("Unexpected result for %s: %+v", id, results[id])
+		}
+	}
+}
+
+func TestBulkAdd(t *testing.T) {
+	http.DefaultServeMux = new(http.ServeMux)
+	http.HandleFunc("/tasks", postTestTask)
+	once.Do(startServer)
+
+	api := New(validToken, OptionAPIURL("http://"+serverAddr+"/"))
+	results := api.BulkAdd([]AddTaskRequest{{Content: "1"}, {Content: "2"}}, BulkOptions{})
+
+	if len(results) != 2 {
+		t.Fatalf("Unexpected results: %+v", results)
+	}
+	for i, result := range results {
+		if result.Err != nil || result.Task == nil || result.Task.Id != "1" {
+			t.Fatalf("Unexpected result %d: %+v", i, result)
+		}
+	}
+}
@@ -0,0 +1,372 @@
+package todoist
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+)
+
+// CacheEntry is a cached response body with its validators.
+type CacheEntry struct {
+	Body         []byte    `json:"body"`
+	ContentType  string    `json:"content_type,omitempty"`
+	ETag         string    `json:"etag,omitempty"`
+	LastModified string    `json:"last_modified,omitempty"`
+	StoredAt     time.Time `json:"stored_at"`
+}
+
+// CacheBackend stores cache entries. Keys are slash separated paths made of
+// hex digits and resource names, so that all entries of a resource share a
+// prefix.
+type CacheBackend interface {
+	Get(key string) (*CacheEntry, bool)
+	Set(key string, entry *CacheEntry) error
+	DeletePrefix(prefix string) error
+}
+
+// cacheInvalidates lists the resources whose cached reads a write to a
+// resource makes stale, besides the resource itself. Writes to projects and
+// Sync API commands drop every entry of the token.
+var cacheInvalidates = map[string][]string{
+	"tasks":    {"completed", "comments"},
+	"sections": {"tasks"},
+	"comments": {"tasks", "projects"},
+	"labels":   {"tasks"},
+	"quick":    {"tasks", "completed"},
+}
+
+// cacheProjectResources lists the resources whose entries are kept per
+// project, below a segment hashing the project_id of the read, or "-" for
+// reads not limited to one project.
+var cacheProjectResources = map[string]bool{
+	"sections": true,
+}
+
+// maxCacheEntrySize is the largest body that is cached. Larger responses are
+// passed on unbuffered after the first maxCacheEntrySize bytes.
+const maxCacheEntrySize = 1 << 20
+
+// CacheInterceptor caches the bodies of GET responses per URL and token, or
+// per account for the clients of a Pool.
+// Responses with an ETag or Last-Modified header are revalidated with
+// If-None-Match or If-Modified-Since on every call and served from the cache
+// on 304 Not Modified. Other responses are served from the cache for ttl
+// without asking the server; with a ttl of 0 they are not cached. Bodies over
+// 1 MiB are never cached.
+//
+// Any other request invalidates the cached reads of its resource and of
+// related resources, e.g. AddSection invalidates the sections of its project
+// and every task. Writes to a section by id, whose project is unknown,
+// invalidate the sections of every project. Sync API requests only
+// invalidate the cache when they carry commands, not when they only read
+// resources.
+func CacheInterceptor(backend CacheBackend, ttl time.Duration) Interceptor {
+	return func(req *http.Request, next Handler) (*http.Response, error) {
+		tokenKey := cacheTokenKey(req)
+		operation, _ := OperationFromContext(req.Context())
+		resource := operation.Resource
+
+		if req.Method != http.MethodGet {
+			resp, err := next(req)
+			invalidateCache(backend, tokenKey, resource, req)
+			return resp, err
+		}
+
+		prefix := tokenKey + resource + "/"
+		if cacheProjectResources[resource] {
+			prefix += cacheProjectKey(req.URL.Query().Get("project_id")) + "/"
+		}
+		key := prefix + hashCacheKey(req.URL.String())
+		entry, cached := backend.Get(key)
+		if cached {
+			validated := entry.ETag != "" || entry.LastModified != ""
+			if !validated && time.Since(entry.StoredAt) < ttl {
+				return entry.response(req), nil
+			}
+			if entry.ETag != "" {
+				req.Header.Set("If-None-Match", entry.ETag)
+			}
+			if entry.LastModified != "" {
+				req.Header.Set("If-Modified-Since", entry.LastModified)
+			}
+		}
+
+		resp, err := next(req)
+		if cached && resp != nil && resp.StatusCode == http.StatusNotModified {
+			_ = resp.Body.Close()
+			entry.StoredAt = time.Now()
+			_ = backend.Set(key, entry)
+			return entry.response(req), nil
+		}
+		if err != nil || resp.StatusCode != http.StatusOK || strings.Contains(resp.Header.Get("Cache-Control"), "no-store") {
+			return resp, err
+		}
+
+		entry = &CacheEntry{
+			ContentType:  resp.Header.Get("Content-Type"),
+			ETag:         resp.Header.Get("ETag"),
+			LastModified: resp.Header.Get("Last-Modified"),
+			StoredAt:     time.Now(),
+		}
+		if entry.ETag == "" && entry.LastModified == "" && ttl <= 0 {
+			return resp, nil
+		}
+		if resp.ContentLength > maxCacheEntrySize {
+			return resp, nil
+		}
+		entry.Body, err = io.ReadAll(io.LimitReader(resp.Body, maxCacheEntrySize+1))
+		if err != nil {
+			_ = resp.Body.Close()
+			return nil, err
+		}
+		if len(entry.Body) > maxCacheEntrySize {
+			resp.Body = struct {
+				io.Reader
+				io.Closer
+			}{io.MultiReader(bytes.NewReader(entry.Body), resp.Body), resp.Body}
+			return resp, nil
+		}
+		_ = resp.Body.Close()
+		resp.Body = io.NopCloser(bytes.NewReader(entry.Body))
+		_ = backend.Set(key, entry)
+		return resp, nil
+	}
+}
+
+func (e *CacheEntry) response(req *http.Request) *http.Response {
+	header := http.Header{}
+	if e.ContentType != "" {
+		header.Set("Content-Type", e.ContentType)
+	}
+	if e.ETag != "" {
+		header.Set("ETag", e.ETag)
+	}
+	if e.LastModified != "" {
+		header.Set("Last-Modified", e.LastModified)
+	}
+	return &http.Response{
+		Status:        "200 OK",
+		StatusCode:    http.StatusOK,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        header,
+		Body:          io.NopCloser(bytes.NewReader(e.Body)),
+		ContentLength: int64(len(e.Body)),
+		Request:       req,
+	}
+}
+
+func invalidateCache(backend CacheBackend, tokenKey string, resource string, req *http.Request) {
+	if resource == "sync" {
+		var body struct {
+			Commands []json.RawMessage `json:"commands"`
+		}
+		if err := json.Unmarshal(cacheRequestBody(req), &body); err == nil && len(body.Commands) == 0 {
+			return
+		}
+	}
+	if resource == "" || resource == "projects" || resource == "sync" {
+		_ = backend.DeletePrefix(tokenKey)
+		return
+	}
+
+	prefix := tokenKey + resource + "/"
+	if project := cacheRequestProject(req); cacheProjectResources[resource] && project != "" {
+		_ = backend.DeletePrefix(prefix + cacheProjectKey(project) + "/")
+		_ = backend.DeletePrefix(prefix + "-/")
+	} else {
+		_ = backend.DeletePrefix(prefix)
+	}
+	for _, related := range cacheInvalidates[resource] {
+		_ = backend.DeletePrefix(tokenKey + related + "/")
+	}
+}
+
+// cacheRequestProject returns the project_id of a JSON request body, if any.
+func cacheRequestProject(req *http.Request) string {
+	var body struct {
+		ProjectId string `json:"project_id"`
+	}
+	_ = json.Unmarshal(cacheRequestBody(req), &body)
+	return body.ProjectId
+}
+
+// cacheRequestBody returns a copy of the request body, leaving the body
+// itself unread.
+func cacheRequestBody(req *http.Request) []byte {
+	if req.GetBody == nil {
+		return nil
+	}
+	body, err := req.GetBody()
+	if err != nil {
+		return nil
+	}
+	defer body.Close()
+	content, _ := io.ReadAll(body)
+	return content
+}
+
+// cacheProjectKey is the key segment of a project_id, hashed so that ids
+// taken from requests cannot add segments to keys.
+func cacheProjectKey(project string) string {
+	if project == "" {
+		return "-"
+	}
+	return hashCacheKey("project:" + project)[:16]
+}
+
+type cacheAccountKey struct{}
+
+// withCacheAccount keys the cache entries of requests by account instead of
+// by token, so that they survive token rotations.
+func withCacheAccount(ctx context.Context, account string) context.Context {
+	return context.WithValue(ctx, cacheAccountKey{}, account)
+}
+
+// cacheTokenKey is the key prefix of the entries of the request's account, as
+// set by a Pool, or else of its token. The token itself is never stored.
+func cacheTokenKey(req *http.Request) string {
+	if account, ok := req.Context().Value(cacheAccountKey{}).(string); ok {
+		return hashCacheKey("account:" + account)[:16] + "/"
+	}
+	return hashCacheKey(req.Header.Get("Authorization"))[:16] + "/"
+}
+
+func hashCacheKey(s string) string {
+	sum := sha256.Sum256([]byte(s))
+	return hex.EncodeToString(sum[:])
+}
+
+// MemoryCache is a CacheBackend keeping entries in memory.
+type MemoryCache struct {
+	mu      sync.Mutex
+	entries map[string]*CacheEntry
+}
+
+func NewMemoryCache() *MemoryCache {
+	return &MemoryCache{entries: map[string]*CacheEntry{}}
+}
+
+func (c *MemoryCache) Get(key string) (*CacheEntry, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	entry, ok := c.entries[key]
+	if !ok {
+		return nil, false
+	}
+	copied := *entry
+	return &copied, true
+}
+
+func (c *MemoryCache) Set(key string, entry *CacheEntry) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	copied := *entry
+	c.entries[key] = &copied
+	return nil
+}
+
+func (c *MemoryCache) DeletePrefix(prefix string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for key := range c.entries {
+		if strings.HasPrefix(key, prefix) {
+			delete(c.entries, key)
+		}
+	}
+	return nil
+}
+
+// DiskCache is a CacheBackend keeping one JSON file per entry below a
+// directory, so that entries survive restarts and can be shared by processes.
+type DiskCache struct {
+	dir string
+}
+
+func NewDiskCache(dir string) (*DiskCache, error) {
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return nil, err
+	}
+	return &DiskCache{dir: dir}, nil
+}
+
+// path returns the file of a key, rejecting keys that leave the directory.
+func (c *DiskCache) path(key string) (string, error) {
+	path := filepath.Join(c.dir, filepath.FromSlash(key))
+	rel, err := filepath.Rel(c.dir, path)
+	if err != nil || rel == "." || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return "", fmt.Errorf("disk cache key %q is outside the cache directory", key)
+	}
+	return path, nil
+}
+
+func (c *DiskCache) Get(key string) (*CacheEntry, bool) {
+	path, err := c.path(key)
+	if err != nil {
+		return nil, false
+	}
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, false
+	}
+	entry := &CacheEntry{}
+	if err := json.Unmarshal(content, entry); err != nil {
+		return nil, false
+	}
+	return entry, true
+}
+
+// Set writes the entry to a temporary file first, so that readers never see
+// a partial entry.
+func (c *DiskCache) Set(key string, entry *CacheEntry) error {
+	path, err := c.path(key)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return err
+	}
+	content, err := json.Marshal(entry)
+	if err != nil {
+		return err
+	}
+	file, err := os.CreateTemp(filepath.Dir(path), ".entry-*")
+	if err != nil {
+		return err
+	}
+	_, err = file.Write(content)
+	if closeErr := file.Close(); err == nil {
+		err = closeErr
+	}
+	if err == nil {
+		err = os.Rename(file.Name(), path)
+	}
+	if err != nil {
+		_ = os.Remove(file.Name())
+	}
+	return err
+}
+
+// DeletePrefix removes the entries below a prefix ending with a slash.
+func (c *DiskCache) DeletePrefix(prefix string) error {
+	if !strings.HasSuffix(prefix, "/") {
+		return errors.New("disk cache prefixes must end with a slash")
+	}
+	path, err := c.path(prefix)
+	if err != nil {
+		return err
+	}
+	return os.RemoveAll(path)
+}
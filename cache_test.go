@@ -0,0 +1,220 @@
+package todoist
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestCacheRevalidatesWithETag(t *testing.T) {
+	http.DefaultServeMux = new(http.ServeMux)
+	calls, notModified := 0, 0
+	http.HandleFunc("/projects", func(rw http.ResponseWriter, r *http.Request) {
+		calls++
+		if r.Header.Get("If-None-Match") == `"v1"` {
+			notModified++
+			rw.WriteHeader(http.StatusNotModified)
+			return
+		}
+		rw.Header().Set("ETag", `"v1"`)
+		getProjects(rw, r)
+	})
+	once.Do(startServer)
+	api := New(validToken, OptionAPIURL("http://"+serverAddr+"/"),
+		OptionInterceptors(CacheInterceptor(NewMemoryCache(), 0)))
+
+	for i := 0; i < 3; i++ {
+		projects, err := api.GetProjects()
+		if err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+		if !reflect.DeepEqual(getTestProjects(), *projects) {
+			t.Fatal(ErrIncorrectResponse)
+		}
+	}
+	if calls != 3 || notModified != 2 {
+		t.Fatalf("Unexpected calls %d, not modified %d", calls, notModified)
+	}
+}
+
+func TestCacheTTLAndInvalidation(t *testing.T) {
+	http.DefaultServeMux = new(http.ServeMux)
+	calls := 0
+	http.HandleFunc("/sections", func(rw http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodPost {
+			_, _ = rw.Write([]byte(`{"id":"3","project_id":"1","name":"New"}`))
+			return
+		}
+		calls++
+		_, _ = rw.Write([]byte(`[{"id":"1","project_id":"1","name":"Groceries"}]`))
+	})
+	once.Do(startServer)
+	api := New(validToken, OptionAPIURL("http://"+serverAddr+"/"),
+		OptionInterceptors(CacheInterceptor(NewMemoryCache(), time.Minute)))
+	other := New("other-token", OptionAPIURL("http://"+serverAddr+"/"),
+		OptionInterceptors(CacheInterceptor(NewMemoryCache(), time.Minute)))
+
+	for i := 0; i < 2; i++ {
+		if _, err := api.GetSectionsByProjectId("1"); err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+	}
+	if calls != 1 {
+		t.Fatalf("Expected a cached response, got %d calls", calls)
+	}
+	if _, err := other.GetSectionsByProjectId("1"); err != nil || calls != 2 {
+		t.Fatalf("Expected entries per token, got %d calls", calls)
+	}
+
+	if _, err := api.GetSectionsByProjectId("2"); err != nil || calls != 3 {
+		t.Fatalf("Expected entries per project, got %d calls", calls)
+	}
+
+	if _, err := api.AddSection(&SectionParameters{ProjectId: "1", Name: "New"}); err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	if _, err := api.GetSectionsByProjectId("1"); err != nil || calls != 4 {
+		t.Fatalf("Expected the entry to be invalidated, got %d calls", calls)
+	}
+	if _, err := api.GetSectionsByProjectId("2"); err != nil || calls != 4 {
+		t.Fatalf("Expected the entry of the other project to be kept, got %d calls", calls)
+	}
+}
+
+func TestCacheInvalidationBySync(t *testing.T) {
+	http.DefaultServeMux = new(http.ServeMux)
+	calls := 0
+	var commands []SyncCommand
+	http.HandleFunc("/projects", func(rw http.ResponseWriter, r *http.Request) {
+		calls++
+		getProjects(rw, r)
+	})
+	http.HandleFunc("/sync", func(rw http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		r.Body = io.NopCloser(bytes.NewReader(body))
+		if bytes.Contains(body, []byte(`"commands"`)) {
+			syncCommandsHandler(&commands)(rw, r)
+			return
+		}
+		getUser(rw, r)
+	})
+	once.Do(startServer)
+	api := New(validToken, OptionAPIURL("http://"+serverAddr+"/"), OptionSyncAPIURL("http://"+serverAddr+"/"),
+		OptionInterceptors(CacheInterceptor(NewMemoryCache(), time.Minute)))
+
+	if _, err := api.GetProjects(); err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	if _, err := api.GetUser(); err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	if _, err := api.GetProjects(); err != nil || calls != 1 {
+		t.Fatalf("Expected reads through the Sync API to keep the cache, got %d calls", calls)
+	}
+
+	if _, err := api.ArchiveSection("1"); err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	if _, err := api.GetProjects(); err != nil || calls != 2 {
+		t.Fatalf("Expected Sync commands to invalidate the cache, got %d calls", calls)
+	}
+}
+
+func TestCacheSkipsLargeBodies(t *testing.T) {
+	http.DefaultServeMux = new(http.ServeMux)
+	calls := 0
+	large := `[{"id":"1","name":"` + strings.Repeat("x", maxCacheEntrySize) + `"}]`
+	http.HandleFunc("/labels", func(rw http.ResponseWriter, r *http.Request) {
+		calls++
+		_, _ = rw.Write([]byte(large))
+	})
+	once.Do(startServer)
+	api := New(validToken, OptionAPIURL("http://"+serverAddr+"/"),
+		OptionInterceptors(CacheInterceptor(NewMemoryCache(), time.Minute)))
+
+	for i := 0; i < 2; i++ {
+		labels, err := api.GetLabels()
+		if err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+		if len(*labels) != 1 || len((*labels)[0].Name) != maxCacheEntrySize {
+			t.Fatal(ErrIncorrectResponse)
+		}
+	}
+	if calls != 2 {
+		t.Fatalf("Expected large bodies not to be cached, got %d calls", calls)
+	}
+}
+
+func TestDiskCache(t *testing.T) {
+	cache, err := NewDiskCache(t.TempDir())
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	entry := &CacheEntry{Body: []byte(`[]`), ETag: `"v1"`, StoredAt: time.Now().UTC().Truncate(time.Second)}
+	if err := cache.Set("abc/tasks/1", entry); err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	if err := cache.Set("abc/labels/2", entry); err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+
+	stored, ok := cache.Get("abc/tasks/1")
+	if !ok || !reflect.DeepEqual(entry, stored) {
+		t.Fatal(ErrIncorrectResponse)
+	}
+	if err := cache.DeletePrefix("abc/tasks/"); err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	if _, ok := cache.Get("abc/tasks/1"); ok {
+		t.Fatal("Expected the entry to be deleted")
+	}
+	if _, ok := cache.Get("abc/labels/2"); !ok {
+		t.Fatal("Expected the entry to be kept")
+	}
+}
+
+func TestDiskCacheKeepsToItsDirectory(t *testing.T) {
+	http.DefaultServeMux = new(http.ServeMux)
+	http.HandleFunc("/sections", func(rw http.ResponseWriter, r *http.Request) {
+		rw.WriteHeader(http.StatusBadRequest)
+	})
+	once.Do(startServer)
+
+	root := t.TempDir()
+	victim := filepath.Join(root, "victim")
+	if err := os.Mkdir(victim, 0o700); err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	cache, err := NewDiskCache(filepath.Join(root, "cache"))
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	api := New(validToken, OptionAPIURL("http://"+serverAddr+"/"),
+		OptionInterceptors(CacheInterceptor(cache, time.Minute)))
+
+	if _, err := api.AddSection(&SectionParameters{ProjectId: "../../../victim", Name: "New"}); err == nil {
+		t.Fatal("Expected an error")
+	}
+	if _, err := os.Stat(victim); err != nil {
+		t.Fatalf("Expected the directory outside the cache to be kept: %s", err)
+	}
+
+	for _, key := range []string{"../victim/1", "abc/../../victim/1", "/"} {
+		if err := cache.Set(key, &CacheEntry{}); err == nil {
+			t.Errorf("Expected an error setting %q", key)
+		}
+	}
+	if err := cache.DeletePrefix("../victim/"); err == nil {
+		t.Error("Expected an error deleting outside the cache")
+	}
+	if _, err := os.Stat(victim); err != nil {
+		t.Fatalf("Expected the directory outside the cache to be kept: %s", err)
+	}
+}
@@ -0,0 +1,77 @@
+package todoist
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/url"
+	"sync"
+)
+
+// OptionCoalesceRequests makes identical GET requests that are in flight at
+// the same time share a single HTTP request. Every caller decodes the shared
+// body into its own value, so results are never aliased. A caller whose
+// context ends stops waiting on its own, and callers still waiting when the
+// context of the request ends send a request of their own.
+func OptionCoalesceRequests() func(*Client) {
+	return func(c *Client) { c.coalescer = &coalescer{calls: map[string]*coalescedCall{}} }
+}
+
+type coalescer struct {
+	mu    sync.Mutex
+	calls map[string]*coalescedCall
+}
+
+type coalescedCall struct {
+	done chan struct{}
+	body []byte
+	err  error
+}
+
+func (api *Client) coalescedGet(ctx context.Context, endpoint string, values url.Values, intf interface{}) error {
+	key := endpoint + "?" + values.Encode()
+
+	api.coalescer.mu.Lock()
+	if call, ok := api.coalescer.calls[key]; ok {
+		api.coalescer.mu.Unlock()
+		select {
+		case <-call.done:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+		if isContextError(call.err) && ctx.Err() == nil {
+			return performGet(ctx, api.httpclient, endpoint, api.token, values, intf, api)
+		}
+		return api.decodeCoalesced(call, intf)
+	}
+	call := &coalescedCall{done: make(chan struct{})}
+	api.coalescer.calls[key] = call
+	api.coalescer.mu.Unlock()
+
+	call.err = performGetWithParser(ctx, api.httpclient, endpoint, api.token, values, func(resp *http.Response) error {
+		var err error
+		call.body, err = io.ReadAll(resp.Body)
+		return err
+	}, api)
+
+	api.coalescer.mu.Lock()
+	delete(api.coalescer.calls, key)
+	api.coalescer.mu.Unlock()
+	close(call.done)
+
+	return api.decodeCoalesced(call, intf)
+}
+
+func (api *Client) decodeCoalesced(call *coalescedCall, intf interface{}) error {
+	if call.err != nil {
+		return call.err
+	}
+	resp := &http.Response{Body: io.NopCloser(bytes.NewReader(call.body))}
+	return newJSONParser(intf, api.strictDecoding)(resp)
+}
+
+func isContextError(err error) bool {
+	return errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded)
+}
@@ -0,0 +1,103 @@
+package todoist
+
+import (
+	"context"
+	"net/http"
+	"reflect"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestCoalesceRequests(t *testing.T) {
+	http.DefaultServeMux = new(http.ServeMux)
+	var calls int32
+	started := make(chan struct{}, 1)
+	release := make(chan struct{})
+	http.HandleFunc("/projects", func(rw http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		started <- struct{}{}
+		<-release
+		getProjects(rw, r)
+	})
+	once.Do(startServer)
+	api := New(validToken, OptionAPIURL("http://"+serverAddr+"/"), OptionCoalesceRequests())
+
+	const callers = 8
+	results := make([]*[]Project, callers)
+	errs := make([]error, callers)
+	var wg sync.WaitGroup
+	call := func(i int) {
+		defer wg.Done()
+		results[i], errs[i] = api.GetProjects()
+	}
+	wg.Add(1)
+	go call(0)
+	<-started
+	for i := 1; i < callers; i++ {
+		wg.Add(1)
+		go call(i)
+	}
+	time.Sleep(50 * time.Millisecond)
+	close(release)
+	wg.Wait()
+
+	if atomic.LoadInt32(&calls) != 1 {
+		t.Fatalf("Expected one request, got %d", calls)
+	}
+	for i := range results {
+		if errs[i] != nil {
+			t.Fatalf("Unexpected error: %s", errs[i])
+		}
+		if !reflect.DeepEqual(getTestProjects(), *results[i]) {
+			t.Fatal(ErrIncorrectResponse)
+		}
+	}
+	(*results[0])[0].Name = "changed"
+	if (*results[1])[0].Name == "changed" {
+		t.Fatal("Results share memory")
+	}
+}
+
+func TestCoalesceRequestsLeaderCanceled(t *testing.T) {
+	http.DefaultServeMux = new(http.ServeMux)
+	var calls int32
+	started := make(chan struct{}, 2)
+	http.HandleFunc("/projects", func(rw http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&calls, 1) == 1 {
+			started <- struct{}{}
+			<-r.Context().Done()
+			return
+		}
+		getProjects(rw, r)
+	})
+	once.Do(startServer)
+	api := New(validToken, OptionAPIURL("http://"+serverAddr+"/"), OptionCoalesceRequests())
+
+	ctx, cancel := context.WithCancel(context.Background())
+	leaderDone := make(chan error)
+	go func() {
+		_, err := api.GetProjectsContext(ctx)
+		leaderDone <- err
+	}()
+	<-started
+
+	followerDone := make(chan error)
+	go func() {
+		_, err := api.GetProjects()
+		followerDone <- err
+	}()
+	time.Sleep(50 * time.Millisecond)
+	cancel()
+
+	if err := <-leaderDone; err == nil {
+		t.Fatal("Expected the leader to fail")
+	}
+	if err := <-followerDone; err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	if atomic.LoadInt32(&calls) != 2 {
+		t.Fatalf("Expected the follower to send its own request, got %d calls", calls)
+	}
+}
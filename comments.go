@@ -23,12 +23,14 @@ type Comment struct {
 	ProjectId  *string     `json:"project_id"`
 	TaskId     *string     `json:"task_id"`
 	Attachment *Attachment `json:"attachment"`
+	Extra      Extra       `json:"-"`
 }
 type Attachment struct {
 	ResourceType string `json:"resource_type"`
 	FileUrl      string `json:"file_url"`
 	FileType     string `json:"file_type"`
 	FileName     string `json:"file_name"`
+	Extra        Extra  `json:"-"`
 }
 
 type NewCommentParameters struct {
@@ -37,6 +39,15 @@ type NewCommentParameters struct {
 	Attachment Attachment `json:"attachment"`
 }
 
+// Validate checks the parameters before AddComment sends them.
+func (p NewCommentParameters) Validate() error {
+	v := newValidator("NewCommentParameters")
+	v.required("task_id", p.TaskId)
+	v.required("content", p.Content)
+	v.maxLength("content", p.Content, maxCommentLength)
+	return v.err()
+}
+
 func (api *Client) GetAllCommentsByProjectId(projectId string) (*[]Comment, error) {
 	return api.GetAllCommentsContext(projectId, "", context.Background())
 }
@@ -81,6 +92,9 @@ func (api *Client) GetAllCommentsContext(projectId string, taskId string, contex
 }
 
 func (api *Client) AddCommentContext(params *NewCommentParameters, context context.Context) (*Comment, error) {
+	if err := params.Validate(); err != nil {
+		return nil, err
+	}
 	response := &CommentResponse{}
 	request, _ := json.Marshal(params)
 	err := api.post(context, "comments", api.token, request, &response.Comment)
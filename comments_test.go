@@ -68,8 +68,8 @@ func TestAddComment(t *testing.T) {
 
 	api := New(validToken, OptionAPIURL("http://"+serverAddr+"/"))
 	param := NewCommentParameters{
-		TaskId:  "",
-		Content: "",
+		TaskId:  "2",
+		Content: "Need one bottle of milk",
 		Attachment: Attachment{
 			ResourceType: "",
 			FileUrl:      "",
@@ -0,0 +1,65 @@
+package todoist
+
+import (
+	"context"
+	"net/url"
+	"strconv"
+)
+
+type CompletedTasksResponse struct {
+	Items []CompletedTask `json:"items"`
+	TodoistResponse
+}
+
+type CompletedTask struct {
+	Id          string  `json:"id"`
+	TaskId      string  `json:"task_id"`
+	UserId      string  `json:"user_id"`
+	ProjectId   string  `json:"project_id"`
+	SectionId   *string `json:"section_id"`
+	Content     string  `json:"content"`
+	CompletedAt string  `json:"completed_at"`
+	NoteCount   int     `json:"note_count"`
+}
+
+// GetCompletedTasksRequest pages through completed tasks, including the ones of
+// archived projects. Since and Until are formatted as 2007-4-29T10:13.
+type GetCompletedTasksRequest struct {
+	ProjectId string // Optional
+	Limit     int    // Optional, the server default is used when 0
+	Offset    int    // Optional
+	Since     string // Optional
+	Until     string // Optional
+}
+
+func (api *Client) GetCompletedTasks(request GetCompletedTasksRequest) (*[]CompletedTask, error) {
+	return api.GetCompletedTasksContext(request, context.Background())
+}
+
+func (api *Client) GetCompletedTasksContext(request GetCompletedTasksRequest, context context.Context) (*[]CompletedTask, error) {
+	response := &CompletedTasksResponse{}
+	values := url.Values{}
+	if request.ProjectId != "" {
+		values.Set("project_id", request.ProjectId)
+	}
+	if request.Limit > 0 {
+		values.Set("limit", strconv.Itoa(request.Limit))
+	}
+	if request.Offset > 0 {
+		values.Set("offset", strconv.Itoa(request.Offset))
+	}
+	if request.Since != "" {
+		values.Set("since", request.Since)
+	}
+	if request.Until != "" {
+		values.Set("until", request.Until)
+	}
+
+	err := api.syncGet(context,
+		"completed/get_all",
+		api.token,
+		values,
+		response)
+
+	return &response.Items, err
+}
@@ -0,0 +1,46 @@
+package todoist
+
+import (
+	"encoding/json"
+	"net/http"
+	"reflect"
+	"testing"
+)
+
+func TestGetCompletedTasks(t *testing.T) {
+	http.DefaultServeMux = new(http.ServeMux)
+	http.HandleFunc("/completed/get_all", getCompletedTasks)
+	expectedTasks := getTestCompletedTasks()
+
+	once.Do(startServer)
+	api := New(validToken, OptionSyncAPIURL("http://"+serverAddr+"/"))
+
+	tasks, err := api.GetCompletedTasks(GetCompletedTasksRequest{ProjectId: "1", Limit: 50})
+	if err != nil {
+		t.Errorf("Unexpected error: %s", err)
+		return
+	}
+	if !reflect.DeepEqual(expectedTasks, *tasks) {
+		t.Fatal(ErrIncorrectResponse)
+	}
+}
+
+func getTestCompletedTasks() []CompletedTask {
+	return []CompletedTask{
+		{Id: "1", TaskId: "10", ProjectId: "1", Content: "done", CompletedAt: "2024-01-02T10:00:00.000000Z"},
+		{Id: "2", TaskId: "11", ProjectId: "1", Content: "also done", CompletedAt: "2024-01-03T10:00:00.000000Z", NoteCount: 2},
+	}
+}
+
+func getCompletedTasks(rw http.ResponseWriter, r *http.Request) {
+	if r.URL.Query().Get("project_id") != "1" || r.URL.Query().Get("limit") != "50" {
+		rw.WriteHeader(http.StatusBadRequest)
+		return
+	}
+	rw.Header().Set("Content-Type", "application/json")
+	response, _ := json.Marshal(CompletedTasksResponse{Items: getTestCompletedTasks()})
+	_, err := rw.Write(response)
+	if err != nil {
+		return
+	}
+}
@@ -0,0 +1,150 @@
+package todoist
+
+import (
+	"sort"
+	"time"
+)
+
+// DurationUnit is the unit of a task duration.
+type DurationUnit string
+
+const (
+	DurationUnitMinute DurationUnit = "minute"
+	DurationUnitDay    DurationUnit = "day"
+)
+
+func (u DurationUnit) Valid() bool {
+	return u == DurationUnitMinute || u == DurationUnitDay
+}
+
+// TaskDuration is how long a task is expected to take.
+type TaskDuration struct {
+	Amount int          `json:"amount"`
+	Unit   DurationUnit `json:"unit"`
+}
+
+// NewTaskDuration converts d to whole days if it is a multiple of a day, and
+// to minutes, rounded up, otherwise.
+func NewTaskDuration(d time.Duration) TaskDuration {
+	const day = 24 * time.Hour
+	if d > 0 && d%day == 0 {
+		return TaskDuration{Amount: int(d / day), Unit: DurationUnitDay}
+	}
+	return TaskDuration{Amount: int((d + time.Minute - 1) / time.Minute), Unit: DurationUnitMinute}
+}
+
+// Duration converts the amount, counting days as 24 hours. Unknown units
+// yield 0.
+func (d TaskDuration) Duration() time.Duration {
+	switch d.Unit {
+	case DurationUnitMinute:
+		return time.Duration(d.Amount) * time.Minute
+	case DurationUnitDay:
+		return time.Duration(d.Amount) * 24 * time.Hour
+	}
+	return 0
+}
+
+// Deadline is the date a task must be done by. Unlike the due date, it does
+// not move when the task is rescheduled.
+type Deadline struct {
+	Date string `json:"date"`
+	Lang string `json:"lang"`
+}
+
+// Time returns the start of the deadline day in location, or in time.Local
+// for a nil location.
+func (d Deadline) Time(location *time.Location) (time.Time, error) {
+	if location == nil {
+		location = time.Local
+	}
+	return time.ParseInLocation("2006-01-02", d.Date, location)
+}
+
+// TimeBlock is the span of time a task is planned for.
+type TimeBlock struct {
+	Task  Task
+	Start time.Time
+	End   time.Time
+}
+
+// Overlaps reports whether the blocks share any time.
+func (b TimeBlock) Overlaps(other TimeBlock) bool {
+	return b.Start.Before(other.End) && other.Start.Before(b.End)
+}
+
+// TimeBlock returns the block of a task due at a specific time and having a
+// duration. Floating due times are read in location.
+func (t Task) TimeBlock(location *time.Location) (TimeBlock, bool) {
+	if t.Due == nil || t.Due.Datetime == "" || t.Duration == nil || t.Duration.Duration() <= 0 {
+		return TimeBlock{}, false
+	}
+	start, err := t.Due.Time(location)
+	if err != nil {
+		return TimeBlock{}, false
+	}
+	return TimeBlock{Task: t, Start: start, End: start.Add(t.Duration.Duration())}, true
+}
+
+// PlanDay lays out tasks between start and end. Tasks due at a specific time
+// keep their slot, even when they overlap; the other tasks having a duration
+// fill the remaining gaps, highest priority first and, for equal priorities,
+// in their given order. Tasks without a duration, outside of the window or
+// not fitting in any gap are returned as unplanned.
+func PlanDay(tasks []Task, start, end time.Time) (planned []TimeBlock, unplanned []Task) {
+	var flexible []Task
+	for _, task := range tasks {
+		if task.Duration == nil || task.Duration.Duration() <= 0 {
+			unplanned = append(unplanned, task)
+			continue
+		}
+		if block, ok := task.TimeBlock(start.Location()); ok {
+			if block.Start.Before(start) || block.End.After(end) {
+				unplanned = append(unplanned, task)
+			} else {
+				planned = append(planned, block)
+			}
+			continue
+		}
+		flexible = append(flexible, task)
+	}
+
+	sort.SliceStable(flexible, func(i, j int) bool {
+		return flexible[i].Priority > flexible[j].Priority
+	})
+	for _, task := range flexible {
+		if block, ok := firstGap(planned, task, start, end); ok {
+			planned = append(planned, block)
+		} else {
+			unplanned = append(unplanned, task)
+		}
+	}
+
+	sort.SliceStable(planned, func(i, j int) bool {
+		return planned[i].Start.Before(planned[j].Start)
+	})
+	return planned, unplanned
+}
+
+// firstGap finds the earliest slot for task between start and end that does
+// not overlap the planned blocks.
+func firstGap(planned []TimeBlock, task Task, start, end time.Time) (TimeBlock, bool) {
+	blocks := append([]TimeBlock(nil), planned...)
+	sort.Slice(blocks, func(i, j int) bool { return blocks[i].Start.Before(blocks[j].Start) })
+
+	candidate := TimeBlock{Task: task, Start: start, End: start.Add(task.Duration.Duration())}
+	for _, block := range blocks {
+		if !candidate.Overlaps(block) {
+			if !block.Start.Before(candidate.End) {
+				break
+			}
+			continue
+		}
+		candidate.Start = block.End
+		candidate.End = block.End.Add(task.Duration.Duration())
+	}
+	if candidate.End.After(end) {
+		return TimeBlock{}, false
+	}
+	return candidate, true
+}
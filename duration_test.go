@@ -0,0 +1,153 @@
+package todoist
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestTaskDurationDecode(t *testing.T) {
+	task := Task{}
+	err := json.Unmarshal([]byte(`{"id":"1","duration":{"amount":90,"unit":"minute"},"deadline":{"date":"2023-05-01","lang":"en"}}`), &task)
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	if task.Duration == nil || task.Duration.Duration() != 90*time.Minute {
+		t.Fatal(ErrIncorrectResponse)
+	}
+	deadline, err := task.Deadline.Time(time.UTC)
+	if err != nil || !deadline.Equal(time.Date(2023, 5, 1, 0, 0, 0, 0, time.UTC)) {
+		t.Fatal(ErrIncorrectResponse)
+	}
+	local, err := task.Deadline.Time(nil)
+	if err != nil || !local.Equal(time.Date(2023, 5, 1, 0, 0, 0, 0, time.Local)) {
+		t.Fatalf("Unexpected local deadline: %s, %v", local, err)
+	}
+}
+
+func TestNewTaskDuration(t *testing.T) {
+	tests := []struct {
+		duration time.Duration
+		expected TaskDuration
+	}{
+		{45 * time.Minute, TaskDuration{45, DurationUnitMinute}},
+		{90 * time.Second, TaskDuration{2, DurationUnitMinute}},
+		{48 * time.Hour, TaskDuration{2, DurationUnitDay}},
+		{25 * time.Hour, TaskDuration{1500, DurationUnitMinute}},
+	}
+	for _, test := range tests {
+		if duration := NewTaskDuration(test.duration); duration != test.expected {
+			t.Errorf("Expected %+v for %s, got %+v", test.expected, test.duration, duration)
+		}
+	}
+	if (TaskDuration{2, DurationUnitDay}).Duration() != 48*time.Hour {
+		t.Fatal(ErrIncorrectResponse)
+	}
+}
+
+func TestAddTaskWithDurationAndDeadline(t *testing.T) {
+	http.DefaultServeMux = new(http.ServeMux)
+	body := map[string]interface{}{}
+	http.HandleFunc("/tasks", func(rw http.ResponseWriter, r *http.Request) {
+		content, _ := io.ReadAll(r.Body)
+		_ = json.Unmarshal(content, &body)
+		_, _ = rw.Write([]byte(`{"id":"1"}`))
+	})
+	once.Do(startServer)
+	api := New(validToken, OptionAPIURL("http://"+serverAddr+"/"))
+
+	_, err := api.AddTask(AddTaskRequest{
+		Content:      "Review design",
+		DueDatetime:  "2023-05-01T09:00:00Z",
+		Duration:     &TaskDuration{30, DurationUnitMinute},
+		DeadlineDate: "2023-05-03",
+	})
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	if body["duration"] != float64(30) || body["duration_unit"] != "minute" || body["deadline_date"] != "2023-05-03" {
+		t.Fatalf("Unexpected body %v", body)
+	}
+
+	for _, request := range []AddTaskRequest{
+		{Content: "a", Duration: &TaskDuration{Amount: 30}},
+		{Content: "a", Duration: &TaskDuration{-1, DurationUnitDay}},
+		{Content: "a", Duration: &TaskDuration{1, "hour"}},
+		{Content: "a", DeadlineDate: "May 3"},
+	} {
+		if err := request.Validate(); err == nil {
+			t.Errorf("Expected an error for %+v", request)
+		}
+	}
+}
+
+func TestAddTaskDurationMarshal(t *testing.T) {
+	content, _ := json.Marshal(AddTaskRequest{Content: "a", Duration: &TaskDuration{2, DurationUnitDay}})
+	var body map[string]interface{}
+	_ = json.Unmarshal(content, &body)
+	if body["duration"] != float64(2) || body["duration_unit"] != "day" {
+		t.Fatalf("Unexpected body %s", content)
+	}
+	content, _ = json.Marshal(AddTaskRequest{Content: "a"})
+	body = nil
+	_ = json.Unmarshal(content, &body)
+	if _, ok := body["duration"]; ok {
+		t.Fatalf("Unexpected body %s", content)
+	}
+}
+
+func TestUpdateTaskDurationMarshal(t *testing.T) {
+	content, _ := json.Marshal(UpdateTaskRequest{Duration: Set(TaskDuration{2, DurationUnitDay}), DeadlineDate: Clear[string]()})
+	if string(content) != `{"deadline_date":null,"duration":2,"duration_unit":"day"}` {
+		t.Fatalf("Unexpected body %s", content)
+	}
+	content, _ = json.Marshal(UpdateTaskRequest{Duration: Clear[TaskDuration]()})
+	if string(content) != `{"duration":null,"duration_unit":null}` {
+		t.Fatalf("Unexpected body %s", content)
+	}
+}
+
+func TestPlanDay(t *testing.T) {
+	minutes := func(n int) *TaskDuration { return &TaskDuration{n, DurationUnitMinute} }
+	tasks := []Task{
+		{Id: "standup", Due: &Due{Datetime: "2023-05-01T09:30:00"}, Duration: minutes(30)},
+		{Id: "low", Priority: PriorityNormal, Duration: minutes(60)},
+		{Id: "urgent", Priority: PriorityUrgent, Due: &Due{Date: "2023-05-01"}, Duration: minutes(45)},
+		{Id: "no duration", Priority: PriorityUrgent},
+		{Id: "too long", Duration: &TaskDuration{1, DurationUnitDay}},
+		{Id: "medium", Priority: PriorityMedium, Duration: minutes(30)},
+	}
+	start := time.Date(2023, 5, 1, 9, 0, 0, 0, time.UTC)
+	end := start.Add(4 * time.Hour)
+
+	planned, unplanned := PlanDay(tasks, start, end)
+
+	at := func(hour, minute int) time.Time { return time.Date(2023, 5, 1, hour, minute, 0, 0, time.UTC) }
+	expected := []struct {
+		id         string
+		start, end time.Time
+	}{
+		{"medium", at(9, 0), at(9, 30)},
+		{"standup", at(9, 30), at(10, 0)},
+		{"urgent", at(10, 0), at(10, 45)},
+		{"low", at(10, 45), at(11, 45)},
+	}
+	if len(planned) != len(expected) {
+		t.Fatalf("Unexpected plan %+v", planned)
+	}
+	for i, block := range planned {
+		if block.Task.Id != expected[i].id || !block.Start.Equal(expected[i].start) || !block.End.Equal(expected[i].end) {
+			t.Errorf("Unexpected block %s %s-%s", block.Task.Id, block.Start, block.End)
+		}
+	}
+	ids := []string{}
+	for _, task := range unplanned {
+		ids = append(ids, task.Id)
+	}
+	if !reflect.DeepEqual([]string{"no duration", "too long"}, ids) {
+		t.Fatalf("Unexpected unplanned tasks %v", ids)
+	}
+}
@@ -0,0 +1,187 @@
+package todoist
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// Priority is a task priority as sent by the API, from PriorityNormal to
+// PriorityUrgent. The UI shows it the other way round: PriorityUrgent is "p1"
+// and PriorityNormal "p4".
+type Priority int
+
+const (
+	PriorityNormal Priority = 1 // "p4" in the UI
+	PriorityMedium Priority = 2 // "p3" in the UI
+	PriorityHigh   Priority = 3 // "p2" in the UI
+	PriorityUrgent Priority = 4 // "p1" in the UI
+)
+
+// PriorityFromUI converts a priority shown in the UI, "p1" to "p4" or 1 to 4,
+// to the API priority.
+func PriorityFromUI(level string) (Priority, error) {
+	trimmed := strings.TrimPrefix(strings.ToLower(level), "p")
+	if len(trimmed) != 1 || trimmed[0] < '1' || trimmed[0] > '4' {
+		return 0, fmt.Errorf("invalid priority %q, expected p1 to p4", level)
+	}
+	return Priority(5 - int(trimmed[0]-'0')), nil
+}
+
+// UI returns the level shown in the UI, 1 for PriorityUrgent.
+func (p Priority) UI() int {
+	return 5 - int(p)
+}
+
+// String returns the priority as shown in the UI, e.g. "p1" for
+// PriorityUrgent.
+func (p Priority) String() string {
+	if !p.Valid() {
+		return fmt.Sprintf("Priority(%d)", int(p))
+	}
+	return fmt.Sprintf("p%d", p.UI())
+}
+
+func (p Priority) Valid() bool {
+	return p >= PriorityNormal && p <= PriorityUrgent
+}
+
+// MarshalJSON writes the API priority, and fails for unknown priorities.
+func (p Priority) MarshalJSON() ([]byte, error) {
+	if err := checkEnum(p); err != nil {
+		return nil, err
+	}
+	return json.Marshal(int(p))
+}
+
+// Color is a named color of projects, labels and filters.
+type Color string
+
+const (
+	ColorBerryRed   Color = "berry_red"
+	ColorRed        Color = "red"
+	ColorOrange     Color = "orange"
+	ColorYellow     Color = "yellow"
+	ColorOliveGreen Color = "olive_green"
+	ColorLimeGreen  Color = "lime_green"
+	ColorGreen      Color = "green"
+	ColorMintGreen  Color = "mint_green"
+	ColorTeal       Color = "teal"
+	ColorSkyBlue    Color = "sky_blue"
+	ColorLightBlue  Color = "light_blue"
+	ColorBlue       Color = "blue"
+	ColorGrape      Color = "grape"
+	ColorViolet     Color = "violet"
+	ColorLavender   Color = "lavender"
+	ColorMagenta    Color = "magenta"
+	ColorSalmon     Color = "salmon"
+	ColorCharcoal   Color = "charcoal"
+	ColorGrey       Color = "grey"
+	ColorTaupe      Color = "taupe"
+)
+
+// Colors lists the palette in the order of the Todoist color picker.
+var Colors = []Color{
+	ColorBerryRed, ColorRed, ColorOrange, ColorYellow, ColorOliveGreen,
+	ColorLimeGreen, ColorGreen, ColorMintGreen, ColorTeal, ColorSkyBlue,
+	ColorLightBlue, ColorBlue, ColorGrape, ColorViolet, ColorLavender,
+	ColorMagenta, ColorSalmon, ColorCharcoal, ColorGrey, ColorTaupe,
+}
+
+var colorHex = map[Color]string{
+	ColorBerryRed:   "#b8256f",
+	ColorRed:        "#db4035",
+	ColorOrange:     "#ff9933",
+	ColorYellow:     "#fad000",
+	ColorOliveGreen: "#afb83b",
+	ColorLimeGreen:  "#7ecc49",
+	ColorGreen:      "#299438",
+	ColorMintGreen:  "#6accbc",
+	ColorTeal:       "#158fad",
+	ColorSkyBlue:    "#14aaf5",
+	ColorLightBlue:  "#96c3eb",
+	ColorBlue:       "#4073ff",
+	ColorGrape:      "#884dff",
+	ColorViolet:     "#af38eb",
+	ColorLavender:   "#eb96eb",
+	ColorMagenta:    "#e05194",
+	ColorSalmon:     "#ff8d85",
+	ColorCharcoal:   "#808080",
+	ColorGrey:       "#b8b8b8",
+	ColorTaupe:      "#ccac93",
+}
+
+// Hex returns the color as "#rrggbb", or "" for unknown colors.
+func (c Color) Hex() string {
+	return colorHex[c]
+}
+
+func (c Color) Valid() bool {
+	_, ok := colorHex[c]
+	return ok
+}
+
+// MarshalJSON fails for colors outside of the palette.
+func (c Color) MarshalJSON() ([]byte, error) {
+	if err := checkEnum(c); err != nil {
+		return nil, err
+	}
+	return json.Marshal(string(c))
+}
+
+// ViewStyle is how a project is displayed.
+type ViewStyle string
+
+const (
+	ViewStyleList     ViewStyle = "list"
+	ViewStyleBoard    ViewStyle = "board"
+	ViewStyleCalendar ViewStyle = "calendar"
+)
+
+var ViewStyles = []ViewStyle{ViewStyleList, ViewStyleBoard, ViewStyleCalendar}
+
+func (s ViewStyle) Valid() bool {
+	return s == ViewStyleList || s == ViewStyleBoard || s == ViewStyleCalendar
+}
+
+// MarshalJSON fails for unknown view styles.
+func (s ViewStyle) MarshalJSON() ([]byte, error) {
+	if err := checkEnum(s); err != nil {
+		return nil, err
+	}
+	return json.Marshal(string(s))
+}
+
+// enum is implemented by the typed constants above.
+type enum interface {
+	Valid() bool
+}
+
+// checkEnum returns an UnknownValueError for a value outside of its enum.
+// The zero value stands for an unset field and is accepted.
+func checkEnum[T interface {
+	comparable
+	enum
+}](value T) error {
+	var zero T
+	if value != zero && !value.Valid() {
+		return &UnknownValueError{Value: value}
+	}
+	return nil
+}
+
+// UnknownValueError is returned when a priority, color or view style outside
+// of its enum is marshaled, or decoded with OptionStrictDecoding, such as a
+// color added to the palette after this package was written. Field is only
+// set by OptionStrictDecoding.
+type UnknownValueError struct {
+	Field string // e.g. "[0].color"
+	Value interface{}
+}
+
+func (e *UnknownValueError) Error() string {
+	if e.Field == "" {
+		return fmt.Sprintf("unknown value %v of %T", e.Value, e.Value)
+	}
+	return fmt.Sprintf("unknown value %v of %T at %s", e.Value, e.Value, e.Field)
+}
@@ -0,0 +1,130 @@
+package todoist
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"testing"
+)
+
+func TestPriorityConversions(t *testing.T) {
+	tests := []struct {
+		ui       string
+		priority Priority
+	}{
+		{"p1", PriorityUrgent},
+		{"P2", PriorityHigh},
+		{"3", PriorityMedium},
+		{"p4", PriorityNormal},
+	}
+	for _, test := range tests {
+		priority, err := PriorityFromUI(test.ui)
+		if err != nil {
+			t.Errorf("Unexpected error: %s", err)
+			continue
+		}
+		if priority != test.priority || priority.UI() != test.priority.UI() {
+			t.Errorf("Expected %d for %s, got %d", test.priority, test.ui, priority)
+		}
+	}
+	if PriorityUrgent.String() != "p1" || PriorityNormal.String() != "p4" || Priority(7).String() != "Priority(7)" {
+		t.Fatal(ErrIncorrectResponse)
+	}
+	if _, err := PriorityFromUI("p5"); err == nil {
+		t.Fatal("Expected an error")
+	}
+}
+
+func TestColors(t *testing.T) {
+	for _, color := range Colors {
+		if !color.Valid() || len(color.Hex()) != 7 {
+			t.Errorf("Unexpected color %s", color)
+		}
+	}
+	if ColorBerryRed.Hex() != "#b8256f" || Color("pink").Valid() || Color("pink").Hex() != "" {
+		t.Fatal(ErrIncorrectResponse)
+	}
+	if !ViewStyleCalendar.Valid() || ViewStyle("gallery").Valid() {
+		t.Fatal(ErrIncorrectResponse)
+	}
+}
+
+func TestEnumJSON(t *testing.T) {
+	var project struct {
+		Color     Color     `json:"color"`
+		ViewStyle ViewStyle `json:"view_style"`
+		Priority  Priority  `json:"priority"`
+	}
+	if err := json.Unmarshal([]byte(`{"color":"grey","view_style":"board","priority":4}`), &project); err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	if project.Color != ColorGrey || project.ViewStyle != ViewStyleBoard || project.Priority != PriorityUrgent {
+		t.Fatal(ErrIncorrectResponse)
+	}
+	content, err := json.Marshal(project)
+	if err != nil || string(content) != `{"color":"grey","view_style":"board","priority":4}` {
+		t.Fatalf("Unexpected JSON %s, error: %v", content, err)
+	}
+
+	// Zero values stand for unset fields.
+	if err := json.Unmarshal([]byte(`{"color":"","view_style":null,"priority":0}`), &project); err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	if project.Color != "" || project.ViewStyle != ViewStyleBoard || project.Priority != 0 {
+		t.Fatal(ErrIncorrectResponse)
+	}
+
+	// Unknown values are passed through, see TestStrictDecoding.
+	if err := json.Unmarshal([]byte(`{"color":"pink","view_style":"gallery","priority":5}`), &project); err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	if project.Color != "pink" || project.ViewStyle != "gallery" || project.Priority != 5 {
+		t.Fatal(ErrIncorrectResponse)
+	}
+	if err := json.Unmarshal([]byte(`{"priority":"p1"}`), &project); err == nil {
+		t.Error("Expected an error for a priority string")
+	}
+	unknownValue := &UnknownValueError{}
+	for _, value := range []interface{}{Color("pink"), ViewStyle("gallery"), Priority(0x7)} {
+		if _, err := json.Marshal(value); !errors.As(err, &unknownValue) {
+			t.Errorf("Unexpected error for %v: %v", value, err)
+		}
+	}
+}
+
+func TestStrictDecoding(t *testing.T) {
+	http.DefaultServeMux = new(http.ServeMux)
+	http.HandleFunc("/projects", func(rw http.ResponseWriter, r *http.Request) {
+		_, _ = rw.Write([]byte(`[{"id":"1","color":"grey","view_style":"board"},{"id":"2","color":"pink"}]`))
+	})
+	http.HandleFunc("/labels", func(rw http.ResponseWriter, r *http.Request) {
+		_, _ = rw.Write([]byte(`[{"id":"1","color":"charcoal"},{"id":"2","color":"neon"}]`))
+	})
+	once.Do(startServer)
+
+	lenient := New(validToken, OptionAPIURL("http://"+serverAddr+"/"))
+	projects, err := lenient.GetProjects()
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	if (*projects)[0].ViewStyle != ViewStyleBoard || (*projects)[1].Color != "pink" {
+		t.Fatal(ErrIncorrectResponse)
+	}
+
+	strict := New(validToken, OptionAPIURL("http://"+serverAddr+"/"), OptionStrictDecoding())
+	_, err = strict.GetProjects()
+	unknownValue := &UnknownValueError{}
+	if !errors.As(err, &unknownValue) || unknownValue.Field != "[1].color" || unknownValue.Value != Color("pink") {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	labels := strict.IterateLabels()
+	defer labels.Close()
+	count := 0
+	for labels.Next() {
+		count++
+	}
+	if count != 1 || !errors.As(labels.Err(), &unknownValue) || unknownValue.Value != Color("neon") {
+		t.Fatalf("Unexpected error: %v", labels.Err())
+	}
+}
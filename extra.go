@@ -0,0 +1,170 @@
+package todoist
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+	"sync"
+)
+
+// Extra holds the JSON fields of a model that this package does not know,
+// e.g. ones added to the API after it was written. They are written back
+// when the model is marshaled.
+type Extra map[string]json.RawMessage
+
+// knownFields caches the JSON keys of model types.
+var knownFields sync.Map // reflect.Type -> map[string]bool
+
+// unmarshalWithExtra decodes data into model, a pointer to an alias of a
+// model type without the UnmarshalJSON method, and the fields model does not
+// know into extra.
+func unmarshalWithExtra(data []byte, model interface{}, extra *Extra) error {
+	if err := json.Unmarshal(data, model); err != nil {
+		return err
+	}
+
+	fields := map[string]json.RawMessage{}
+	if err := json.Unmarshal(data, &fields); err != nil {
+		// null, which leaves the model unchanged
+		return nil
+	}
+	known := jsonFieldNames(reflect.TypeOf(model).Elem())
+	*extra = nil
+	for key, value := range fields {
+		if !known[key] {
+			if *extra == nil {
+				*extra = Extra{}
+			}
+			(*extra)[key] = value
+		}
+	}
+	return nil
+}
+
+// marshalWithExtra encodes model, an alias of a model type without the
+// MarshalJSON method, together with its unknown fields. Known fields win over
+// unknown ones of the same name.
+func marshalWithExtra(model interface{}, extra Extra) ([]byte, error) {
+	data, err := json.Marshal(model)
+	if err != nil || len(extra) == 0 {
+		return data, err
+	}
+
+	fields := map[string]json.RawMessage{}
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return nil, err
+	}
+	for key, value := range extra {
+		if _, ok := fields[key]; !ok {
+			fields[key] = value
+		}
+	}
+	return json.Marshal(fields)
+}
+
+func jsonFieldNames(t reflect.Type) map[string]bool {
+	if cached, ok := knownFields.Load(t); ok {
+		return cached.(map[string]bool)
+	}
+	names := map[string]bool{}
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if field.Anonymous && field.Type.Kind() == reflect.Struct {
+			for name := range jsonFieldNames(field.Type) {
+				names[name] = true
+			}
+			continue
+		}
+		if field.IsExported() && field.Tag.Get("json") != "-" {
+			names[jsonFieldName(field)] = true
+		}
+	}
+	knownFields.Store(t, names)
+	return names
+}
+
+// UnknownFieldsError is returned with OptionStrictDecoding when a response
+// holds fields that this package does not know.
+type UnknownFieldsError struct {
+	Field  string // path of the model, e.g. "[0]"
+	Fields []string
+}
+
+func (e *UnknownFieldsError) Error() string {
+	path := e.Field
+	if path == "" {
+		path = "response"
+	}
+	return fmt.Sprintf("unknown fields %s in %s", strings.Join(e.Fields, ", "), path)
+}
+
+func (t *Task) UnmarshalJSON(data []byte) error {
+	type task Task
+	return unmarshalWithExtra(data, (*task)(t), &t.Extra)
+}
+
+func (t Task) MarshalJSON() ([]byte, error) {
+	type task Task
+	return marshalWithExtra(task(t), t.Extra)
+}
+
+func (p *Project) UnmarshalJSON(data []byte) error {
+	type project Project
+	return unmarshalWithExtra(data, (*project)(p), &p.Extra)
+}
+
+func (p Project) MarshalJSON() ([]byte, error) {
+	type project Project
+	return marshalWithExtra(project(p), p.Extra)
+}
+
+func (s *Section) UnmarshalJSON(data []byte) error {
+	type section Section
+	return unmarshalWithExtra(data, (*section)(s), &s.Extra)
+}
+
+func (s Section) MarshalJSON() ([]byte, error) {
+	type section Section
+	return marshalWithExtra(section(s), s.Extra)
+}
+
+func (c *Comment) UnmarshalJSON(data []byte) error {
+	type comment Comment
+	return unmarshalWithExtra(data, (*comment)(c), &c.Extra)
+}
+
+func (c Comment) MarshalJSON() ([]byte, error) {
+	type comment Comment
+	return marshalWithExtra(comment(c), c.Extra)
+}
+
+func (a *Attachment) UnmarshalJSON(data []byte) error {
+	type attachment Attachment
+	return unmarshalWithExtra(data, (*attachment)(a), &a.Extra)
+}
+
+func (a Attachment) MarshalJSON() ([]byte, error) {
+	type attachment Attachment
+	return marshalWithExtra(attachment(a), a.Extra)
+}
+
+func (l *Label) UnmarshalJSON(data []byte) error {
+	type label Label
+	return unmarshalWithExtra(data, (*label)(l), &l.Extra)
+}
+
+func (l Label) MarshalJSON() ([]byte, error) {
+	type label Label
+	return marshalWithExtra(label(l), l.Extra)
+}
+
+func (i *syncItem) UnmarshalJSON(data []byte) error {
+	type item syncItem
+	return unmarshalWithExtra(data, (*item)(i), &i.Extra)
+}
+
+func (p *syncProject) UnmarshalJSON(data []byte) error {
+	type project syncProject
+	return unmarshalWithExtra(data, (*project)(p), &p.Extra)
+}
@@ -0,0 +1,76 @@
+package todoist
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"reflect"
+	"testing"
+)
+
+func TestTaskExtraRoundTrip(t *testing.T) {
+	data := `{"id":"1","content":"Buy milk","labels":null,"priority":1,"due":null,"duration":null,"deadline":null,"is_collapsed":true,"sync_id":"7"}`
+
+	task := Task{}
+	if err := json.Unmarshal([]byte(data), &task); err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	expectedExtra := Extra{"is_collapsed": json.RawMessage(`true`), "sync_id": json.RawMessage(`"7"`)}
+	if task.Id != "1" || task.Content != "Buy milk" || !reflect.DeepEqual(expectedExtra, task.Extra) {
+		t.Fatalf("Unexpected task %+v", task)
+	}
+
+	task.Content = "Buy oat milk"
+	content, err := json.Marshal(task)
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	fields := map[string]interface{}{}
+	_ = json.Unmarshal(content, &fields)
+	if fields["content"] != "Buy oat milk" || fields["is_collapsed"] != true || fields["sync_id"] != "7" {
+		t.Fatalf("Unexpected body %s", content)
+	}
+}
+
+func TestModelsWithoutExtra(t *testing.T) {
+	project := Project{}
+	if err := json.Unmarshal([]byte(`{"id":"1","name":"Inbox"}`), &project); err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	if project.Extra != nil {
+		t.Fatal(ErrIncorrectResponse)
+	}
+
+	comment := Comment{}
+	if err := json.Unmarshal([]byte(`{"id":"1","attachment":{"file_name":"a.pdf","file_size":12}}`), &comment); err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	if comment.Extra != nil || string(comment.Attachment.Extra["file_size"]) != "12" {
+		t.Fatal(ErrIncorrectResponse)
+	}
+}
+
+func TestStrictDecodingUnknownFields(t *testing.T) {
+	http.DefaultServeMux = new(http.ServeMux)
+	http.HandleFunc("/sections", func(rw http.ResponseWriter, r *http.Request) {
+		_, _ = rw.Write([]byte(`[{"id":"1","name":"Groceries"},{"id":"2","name":"Pharmacy","section_order":2,"collapsed":false}]`))
+	})
+	once.Do(startServer)
+
+	lenient := New(validToken, OptionAPIURL("http://"+serverAddr+"/"))
+	sections, err := lenient.GetSectionsByProjectId("1")
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	if len((*sections)[1].Extra) != 2 {
+		t.Fatal(ErrIncorrectResponse)
+	}
+
+	strict := New(validToken, OptionAPIURL("http://"+serverAddr+"/"), OptionStrictDecoding())
+	_, err = strict.GetSectionsByProjectId("1")
+	unknownFields := &UnknownFieldsError{}
+	if !errors.As(err, &unknownFields) || unknownFields.Field != "[1]" ||
+		!reflect.DeepEqual([]string{"collapsed", "section_order"}, unknownFields.Fields) {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+}
@@ -0,0 +1,198 @@
+package todoist
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+type FiltersResponse struct {
+	Filters []Filter `json:"filters"`
+	SyncResponse
+}
+
+type Filter struct {
+	Id         string `json:"id"`
+	Name       string `json:"name"`
+	Query      string `json:"query"`
+	Color      Color  `json:"color"`
+	Order      int    `json:"item_order"`
+	IsFavorite bool   `json:"is_favorite"`
+	IsDeleted  bool   `json:"is_deleted"`
+}
+
+type FilterRequest struct {
+	Name       string `json:"name,omitempty"`        // Required on add
+	Query      string `json:"query,omitempty"`       // Required on add
+	Color      Color  `json:"color,omitempty"`       // Optional
+	Order      *int   `json:"item_order,omitempty"`  // Optional
+	IsFavorite *bool  `json:"is_favorite,omitempty"` // Optional
+}
+
+// FilterResult holds the active tasks matching one of the comma-separated
+// sub-queries of a filter.
+type FilterResult struct {
+	Query string
+	Tasks []Task
+}
+
+type filterArgs struct {
+	Id string `json:"id,omitempty"`
+	FilterRequest
+}
+
+func (api *Client) GetFilters() (*[]Filter, error) {
+	return api.GetFiltersContext(context.Background())
+}
+func (api *Client) AddFilter(request FilterRequest) (*Filter, error) {
+	return api.AddFilterContext(request, context.Background())
+}
+func (api *Client) UpdateFilter(id string, request FilterRequest) (*TodoistResponse, error) {
+	return api.UpdateFilterContext(id, request, context.Background())
+}
+func (api *Client) DeleteFilter(id string) (*TodoistResponse, error) {
+	return api.DeleteFilterContext(id, context.Background())
+}
+func (api *Client) ReorderFilters(orders map[string]int) (*TodoistResponse, error) {
+	return api.ReorderFiltersContext(orders, context.Background())
+}
+func (api *Client) RunFilter(name string) (*[]FilterResult, error) {
+	return api.RunFilterContext(name, context.Background())
+}
+
+func (api *Client) GetFiltersContext(context context.Context) (*[]Filter, error) {
+	response := &FiltersResponse{}
+
+	err := api.readResources(context, []string{"filters"}, response)
+	if err != nil {
+		return nil, err
+	}
+
+	filters := make([]Filter, 0, len(response.Filters))
+	for _, filter := range response.Filters {
+		if !filter.IsDeleted {
+			filters = append(filters, filter)
+		}
+	}
+	return &filters, nil
+}
+
+func (api *Client) AddFilterContext(request FilterRequest, context context.Context) (*Filter, error) {
+	if request.Name == "" || request.Query == "" {
+		return nil, fmt.Errorf("name and query are required to add a filter")
+	}
+
+	command := newSyncCommandWithTempId("filter_add", filterArgs{FilterRequest: request})
+	response, err := api.executeCommands(context, command)
+	if err != nil {
+		return nil, err
+	}
+
+	filter := &Filter{
+		Id:    response.TempIdMapping[command.TempId],
+		Name:  request.Name,
+		Query: request.Query,
+		Color: request.Color,
+	}
+	if request.Order != nil {
+		filter.Order = *request.Order
+	}
+	if request.IsFavorite != nil {
+		filter.IsFavorite = *request.IsFavorite
+	}
+	return filter, nil
+}
+
+func (api *Client) UpdateFilterContext(id string, request FilterRequest, context context.Context) (*TodoistResponse, error) {
+	_, err := api.executeCommands(context, newSyncCommand("filter_update", filterArgs{Id: id, FilterRequest: request}))
+	if err != nil {
+		return nil, err
+	}
+
+	return &TodoistResponse{Ok: true}, nil
+}
+
+func (api *Client) DeleteFilterContext(id string, context context.Context) (*TodoistResponse, error) {
+	_, err := api.executeCommands(context, newSyncCommand("filter_delete", map[string]string{
+		"id": id,
+	}))
+	if err != nil {
+		return nil, err
+	}
+
+	return &TodoistResponse{Ok: true}, nil
+}
+
+func (api *Client) ReorderFiltersContext(orders map[string]int, context context.Context) (*TodoistResponse, error) {
+	_, err := api.executeCommands(context, newSyncCommand("filter_update_orders", map[string]map[string]int{
+		"id_order_mapping": orders,
+	}))
+	if err != nil {
+		return nil, err
+	}
+
+	return &TodoistResponse{Ok: true}, nil
+}
+
+// RunFilterContext looks up the saved filter by name and returns the active
+// tasks of each of its sub-queries, in the order they appear in the query.
+func (api *Client) RunFilterContext(name string, context context.Context) (*[]FilterResult, error) {
+	filters, err := api.GetFiltersContext(context)
+	if err != nil {
+		return nil, err
+	}
+
+	var filter *Filter
+	for i := range *filters {
+		if (*filters)[i].Name == name {
+			filter = &(*filters)[i]
+			break
+		}
+		if filter == nil && strings.EqualFold((*filters)[i].Name, name) {
+			filter = &(*filters)[i]
+		}
+	}
+	if filter == nil {
+		return nil, fmt.Errorf("filter %q not found", name)
+	}
+
+	results := make([]FilterResult, 0)
+	for _, query := range SplitFilterQuery(filter.Query) {
+		tasks, err := api.GetActiveTasksContext(GetActiveTasksRequest{Filter: query}, context)
+		if err != nil {
+			return nil, err
+		}
+		results = append(results, FilterResult{Query: query, Tasks: *tasks})
+	}
+	return &results, nil
+}
+
+// SplitFilterQuery splits a filter query on the commas that separate its
+// sub-queries. Commas inside parentheses or quotes are kept.
+func SplitFilterQuery(query string) []string {
+	var queries []string
+	depth, quoted, start := 0, false, 0
+
+	add := func(end int) {
+		if part := strings.TrimSpace(query[start:end]); part != "" {
+			queries = append(queries, part)
+		}
+	}
+	for i, r := range query {
+		switch {
+		case r == '"':
+			quoted = !quoted
+		case quoted:
+		case r == '(':
+			depth++
+		case r == ')' && depth > 0:
+			depth--
+		case r == ',' && depth == 0:
+			add(i)
+			start = i + 1
+		}
+	}
+	add(len(query))
+
+	return queries
+}
@@ -0,0 +1,113 @@
+package todoist
+
+import (
+	"encoding/json"
+	"net/http"
+	"reflect"
+	"testing"
+)
+
+func TestGetFilters(t *testing.T) {
+	http.DefaultServeMux = new(http.ServeMux)
+	http.HandleFunc("/sync", getFilters)
+	once.Do(startServer)
+	expectedFilters := getTestFilters()
+
+	api := New(validToken, OptionSyncAPIURL("http://"+serverAddr+"/"))
+	filters, err := api.GetFilters()
+	if err != nil {
+		t.Errorf("Unexpected error: %s", err)
+		return
+	}
+	if !reflect.DeepEqual(expectedFilters, *filters) {
+		t.Fatal(ErrIncorrectResponse)
+	}
+}
+
+func TestAddFilter(t *testing.T) {
+	http.DefaultServeMux = new(http.ServeMux)
+	var commands []SyncCommand
+	http.HandleFunc("/sync", syncCommandsHandler(&commands))
+	once.Do(startServer)
+
+	api := New(validToken, OptionSyncAPIURL("http://"+serverAddr+"/"))
+	filter, err := api.AddFilter(FilterRequest{Name: "Urgent", Query: "p1 & today"})
+	if err != nil {
+		t.Errorf("Unexpected error: %s", err)
+		return
+	}
+	if len(commands) != 1 || commands[0].Type != "filter_add" {
+		t.Fatalf("Unexpected commands: %+v", commands)
+	}
+	expectedFilter := Filter{Id: "id-" + commands[0].TempId, Name: "Urgent", Query: "p1 & today"}
+	if !reflect.DeepEqual(expectedFilter, *filter) {
+		t.Fatal(ErrIncorrectResponse)
+	}
+}
+
+func TestReorderFilters(t *testing.T) {
+	http.DefaultServeMux = new(http.ServeMux)
+	var commands []SyncCommand
+	http.HandleFunc("/sync", syncCommandsHandler(&commands))
+	once.Do(startServer)
+
+	api := New(validToken, OptionSyncAPIURL("http://"+serverAddr+"/"))
+	_, err := api.ReorderFilters(map[string]int{"1": 2, "2": 1})
+	if err != nil {
+		t.Errorf("Unexpected error: %s", err)
+		return
+	}
+	if len(commands) != 1 || string(commands[0].Args.(json.RawMessage)) != `{"id_order_mapping":{"1":2,"2":1}}` {
+		t.Fatalf("Unexpected commands: %+v", commands)
+	}
+}
+
+func TestRunFilter(t *testing.T) {
+	http.DefaultServeMux = new(http.ServeMux)
+	http.HandleFunc("/sync", getFilters)
+	http.HandleFunc("/tasks", func(rw http.ResponseWriter, r *http.Request) {
+		response, _ := json.Marshal([]Task{getTestTaskWithId(r.URL.Query().Get("filter"))})
+		_, _ = rw.Write(response)
+	})
+	once.Do(startServer)
+	expectedResults := []FilterResult{
+		{Query: "today", Tasks: []Task{getTestTaskWithId("today")}},
+		{Query: "overdue", Tasks: []Task{getTestTaskWithId("overdue")}},
+	}
+
+	url := "http://" + serverAddr + "/"
+	api := New(validToken, OptionAPIURL(url), OptionSyncAPIURL(url))
+	results, err := api.RunFilter("daily")
+	if err != nil {
+		t.Errorf("Unexpected error: %s", err)
+		return
+	}
+	if !reflect.DeepEqual(expectedResults, *results) {
+		t.Fatal(ErrIncorrectResponse)
+	}
+}
+
+func TestSplitFilterQuery(t *testing.T) {
+	queries := SplitFilterQuery(`today, (p1 | p2) & #"Work, Home",overdue,`)
+	expected := []string{"today", `(p1 | p2) & #"Work, Home"`, "overdue"}
+	if !reflect.DeepEqual(expected, queries) {
+		t.Fatalf("Unexpected queries: %q", queries)
+	}
+}
+
+func getTestFilters() []Filter {
+	return []Filter{
+		{Id: "1", Name: "Daily", Query: "today, overdue", Color: "red", Order: 1},
+		{Id: "2", Name: "Work", Query: "#Work", Color: "blue", Order: 2, IsFavorite: true},
+	}
+}
+
+func getFilters(rw http.ResponseWriter, _ *http.Request) {
+	filters := append(getTestFilters(), Filter{Id: "3", Name: "Removed", IsDeleted: true})
+	response, _ := json.Marshal(FiltersResponse{Filters: filters})
+	rw.Header().Set("Content-Type", "application/json")
+	_, err := rw.Write(response)
+	if err != nil {
+		return
+	}
+}
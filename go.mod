@@ -1,5 +1,5 @@
 module github.com/volyanyk/todoist
 
-go 1.19
+go 1.21
 
 require github.com/google/uuid v1.3.0
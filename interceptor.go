@@ -0,0 +1,59 @@
+package todoist
+
+import (
+	"net/http"
+)
+
+// Handler sends a request. It returns the response together with the error
+// the client decoded from it, such as a StatusCodeError or RateLimitedError,
+// so the response may be non-nil even when the error is not.
+type Handler func(req *http.Request) (*http.Response, error)
+
+// Interceptor runs around every request the client sends. It may change the
+// request before passing it to next, inspect or replace the response and
+// error returned by next, or return a response of its own without calling
+// next at all, e.g. from a cache. A returned response must have a non-nil
+// Body, which the client closes after decoding it; an interceptor discarding
+// the response of next closes that one itself.
+//
+// Interceptors run in the order they were registered: the first one sees the
+// request first and the response last. Rate limiting happens after the
+// innermost interceptor, so short-circuited requests do not use the budget.
+type Interceptor func(req *http.Request, next Handler) (*http.Response, error)
+
+// OptionInterceptors appends interceptors to the client's chain.
+func OptionInterceptors(interceptors ...Interceptor) func(*Client) {
+	return func(c *Client) { c.interceptors = append(c.interceptors, interceptors...) }
+}
+
+// RequestInterceptor adapts a function that prepares requests, for instance
+// by setting headers. Returning an error aborts the request.
+func RequestInterceptor(fn func(req *http.Request) error) Interceptor {
+	return func(req *http.Request, next Handler) (*http.Response, error) {
+		if err := fn(req); err != nil {
+			return nil, err
+		}
+		return next(req)
+	}
+}
+
+// ResponseInterceptor adapts a function that observes every response and the
+// error decoded from it. resp is nil when the request failed before a
+// response was received.
+func ResponseInterceptor(fn func(req *http.Request, resp *http.Response, err error)) Interceptor {
+	return func(req *http.Request, next Handler) (*http.Response, error) {
+		resp, err := next(req)
+		fn(req, resp, err)
+		return resp, err
+	}
+}
+
+func chainInterceptors(interceptors []Interceptor, handler Handler) Handler {
+	for i := len(interceptors) - 1; i >= 0; i-- {
+		interceptor, next := interceptors[i], handler
+		handler = func(req *http.Request) (*http.Response, error) {
+			return interceptor(req, next)
+		}
+	}
+	return handler
+}
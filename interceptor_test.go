@@ -0,0 +1,111 @@
+package todoist
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestInterceptorsOrderAndHeaders(t *testing.T) {
+	http.DefaultServeMux = new(http.ServeMux)
+	http.HandleFunc("/projects", func(rw http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("X-Trace") != "outer,inner" {
+			rw.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		getProjects(rw, r)
+	})
+	once.Do(startServer)
+	expectedProjects := getTestProjects()
+
+	var calls []string
+	trace := func(name string) Interceptor {
+		return func(req *http.Request, next Handler) (*http.Response, error) {
+			calls = append(calls, name+" request")
+			if previous := req.Header.Get("X-Trace"); previous != "" {
+				name = previous + "," + name
+			}
+			req.Header.Set("X-Trace", name)
+			resp, err := next(req)
+			calls = append(calls, name+" response")
+			return resp, err
+		}
+	}
+	api := New(validToken,
+		OptionAPIURL("http://"+serverAddr+"/"),
+		OptionInterceptors(trace("outer"), trace("inner")))
+
+	projects, err := api.GetProjects()
+	if err != nil {
+		t.Errorf("Unexpected error: %s", err)
+		return
+	}
+	if !reflect.DeepEqual(expectedProjects, *projects) {
+		t.Fatal(ErrIncorrectResponse)
+	}
+	expectedCalls := []string{"outer request", "inner request", "outer,inner response", "outer response"}
+	if !reflect.DeepEqual(expectedCalls, calls) {
+		t.Fatalf("Unexpected calls: %v", calls)
+	}
+}
+
+func TestInterceptorShortCircuit(t *testing.T) {
+	http.DefaultServeMux = new(http.ServeMux)
+	once.Do(startServer)
+	expectedLabel := Label{ID: "1", Name: "cached"}
+
+	api := New(validToken,
+		OptionAPIURL("http://"+serverAddr+"/"),
+		OptionInterceptors(func(req *http.Request, next Handler) (*http.Response, error) {
+			return &http.Response{
+				StatusCode: http.StatusOK,
+				Body:       io.NopCloser(strings.NewReader(`{"id":"1","name":"cached"}`)),
+				Request:    req,
+			}, nil
+		}))
+
+	label, err := api.GetLabelById("1")
+	if err != nil {
+		t.Errorf("Unexpected error: %s", err)
+		return
+	}
+	if !reflect.DeepEqual(expectedLabel, *label) {
+		t.Fatal(ErrIncorrectResponse)
+	}
+}
+
+func TestResponseInterceptorObservesErrors(t *testing.T) {
+	http.DefaultServeMux = new(http.ServeMux)
+	http.HandleFunc("/tasks/1", http.NotFound)
+	once.Do(startServer)
+
+	var observed error
+	var status int
+	api := New(validToken,
+		OptionAPIURL("http://"+serverAddr+"/"),
+		OptionInterceptors(ResponseInterceptor(func(req *http.Request, resp *http.Response, err error) {
+			observed = err
+			status = resp.StatusCode
+		})))
+
+	_, err := api.GetActiveTaskById("1")
+	statusCodeError := StatusCodeError{}
+	if !errors.As(observed, &statusCodeError) || status != http.StatusNotFound || err != observed {
+		t.Fatalf("Unexpected observed error: %v", observed)
+	}
+}
+
+func TestRequestInterceptorAborts(t *testing.T) {
+	aborted := errors.New("aborted")
+	api := New(validToken, OptionInterceptors(RequestInterceptor(func(req *http.Request) error {
+		return aborted
+	})))
+
+	_, err := api.GetProjects()
+	if !errors.Is(err, aborted) {
+		t.Fatalf("Expected the interceptor error, got %v", err)
+	}
+}
@@ -0,0 +1,244 @@
+package todoist
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+)
+
+// Iterator streams the elements of a list endpoint. The JSON array is decoded
+// one element at a time, so only the current element is held in memory.
+// Paginated responses of the form {"results": [...], "next_cursor": "..."}
+// are followed transparently.
+//
+//	it := api.IterateActiveTasks(todoist.GetActiveTasksRequest{})
+//	defer it.Close()
+//	for it.Next() {
+//		task := it.Value()
+//	}
+//	if err := it.Err(); err != nil {
+//	}
+//
+// An Iterator must be closed when it is abandoned before Next returns false.
+type Iterator[T any] struct {
+	items   chan T
+	done    chan struct{}
+	cancel  context.CancelFunc
+	current T
+	err     error
+	closed  bool
+	strict  bool
+}
+
+func newIterator[T any](ctx context.Context, api *Client, path string, values url.Values) *Iterator[T] {
+	ctx, cancel := context.WithCancel(ctx)
+	it := &Iterator[T]{
+		items:  make(chan T),
+		done:   make(chan struct{}),
+		cancel: cancel,
+		strict: api.strictDecoding,
+	}
+
+	go func() {
+		defer close(it.done)
+		defer close(it.items)
+		it.err = it.run(ctx, api, path, values)
+	}()
+
+	return it
+}
+
+func newFailedIterator[T any](err error) *Iterator[T] {
+	it := &Iterator[T]{
+		items:  make(chan T),
+		done:   make(chan struct{}),
+		cancel: func() {},
+		err:    err,
+	}
+	close(it.items)
+	close(it.done)
+	return it
+}
+
+func (it *Iterator[T]) run(ctx context.Context, api *Client, path string, values url.Values) error {
+	cursor := ""
+	for {
+		page := url.Values{}
+		for k, v := range values {
+			page[k] = v
+		}
+		if cursor != "" {
+			page.Set("cursor", cursor)
+		}
+
+		next := ""
+		err := performGetWithParser(ctx, api.httpclient, api.endpoint+path, api.token, page, func(resp *http.Response) error {
+			var err error
+			next, err = it.decode(ctx, json.NewDecoder(resp.Body))
+			return err
+		}, api)
+		if err != nil {
+			return err
+		}
+		if next == "" {
+			return nil
+		}
+		cursor = next
+	}
+}
+
+// decode streams either a bare JSON array or a page object and returns the
+// cursor of the next page, if any.
+func (it *Iterator[T]) decode(ctx context.Context, decoder *json.Decoder) (string, error) {
+	token, err := decoder.Token()
+	if err != nil {
+		return "", err
+	}
+	switch token {
+	case json.Delim('['):
+		return "", it.decodeArray(ctx, decoder)
+	case json.Delim('{'):
+	default:
+		return "", fmt.Errorf("unexpected JSON token %v in list response", token)
+	}
+
+	next := ""
+	for decoder.More() {
+		key, err := decoder.Token()
+		if err != nil {
+			return "", err
+		}
+		switch key {
+		case "results":
+			token, err := decoder.Token()
+			if err != nil {
+				return "", err
+			}
+			if token == nil {
+				continue
+			}
+			if token != json.Delim('[') {
+				return "", fmt.Errorf("unexpected JSON token %v for results", token)
+			}
+			if err := it.decodeArray(ctx, decoder); err != nil {
+				return "", err
+			}
+		case "next_cursor":
+			var cursor *string
+			if err := decoder.Decode(&cursor); err != nil {
+				return "", err
+			}
+			if cursor != nil {
+				next = *cursor
+			}
+		default:
+			var skipped json.RawMessage
+			if err := decoder.Decode(&skipped); err != nil {
+				return "", err
+			}
+		}
+	}
+	_, err = decoder.Token()
+	return next, err
+}
+
+func (it *Iterator[T]) decodeArray(ctx context.Context, decoder *json.Decoder) error {
+	for decoder.More() {
+		var item T
+		if err := decoder.Decode(&item); err != nil {
+			return err
+		}
+		if it.strict {
+			if err := checkStrict(item); err != nil {
+				return err
+			}
+		}
+		select {
+		case it.items <- item:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+	_, err := decoder.Token()
+	return err
+}
+
+// Next advances to the next element. It returns false when the list is
+// exhausted, the context is done or an error occurred.
+func (it *Iterator[T]) Next() bool {
+	item, ok := <-it.items
+	if !ok {
+		<-it.done
+		return false
+	}
+	it.current = item
+	return true
+}
+
+// Value returns the element Next advanced to.
+func (it *Iterator[T]) Value() T {
+	return it.current
+}
+
+// Err returns the error that stopped the iteration, if any. Stopping early
+// through Close is not an error.
+func (it *Iterator[T]) Err() error {
+	if it.closed {
+		return nil
+	}
+	select {
+	case <-it.done:
+		return it.err
+	default:
+		return nil
+	}
+}
+
+// Close stops the iteration and releases the underlying response.
+func (it *Iterator[T]) Close() error {
+	it.closed = true
+	it.cancel()
+	for range it.items {
+	}
+	<-it.done
+	return nil
+}
+
+func (api *Client) IterateActiveTasks(request GetActiveTasksRequest) *Iterator[Task] {
+	return api.IterateActiveTasksContext(request, context.Background())
+}
+func (api *Client) IterateProjects() *Iterator[Project] {
+	return api.IterateProjectsContext(context.Background())
+}
+func (api *Client) IterateAllCommentsByProjectId(projectId string) *Iterator[Comment] {
+	return api.IterateAllCommentsContext(projectId, "", context.Background())
+}
+func (api *Client) IterateAllCommentsByTaskId(taskId string) *Iterator[Comment] {
+	return api.IterateAllCommentsContext("", taskId, context.Background())
+}
+func (api *Client) IterateLabels() *Iterator[Label] {
+	return api.IterateLabelsContext(context.Background())
+}
+
+func (api *Client) IterateActiveTasksContext(request GetActiveTasksRequest, context context.Context) *Iterator[Task] {
+	return newIterator[Task](context, api, "tasks", request.values())
+}
+func (api *Client) IterateProjectsContext(context context.Context) *Iterator[Project] {
+	return newIterator[Project](context, api, "projects", url.Values{})
+}
+func (api *Client) IterateAllCommentsContext(projectId string, taskId string, context context.Context) *Iterator[Comment] {
+	values := url.Values{}
+	if projectId != "" {
+		values.Set("project_id", projectId)
+	} else if taskId != "" {
+		values.Set("task_id", taskId)
+	} else {
+		return newFailedIterator[Comment](fmt.Errorf("task_id or project_id are missing on input"))
+	}
+	return newIterator[Comment](context, api, "comments", values)
+}
+func (api *Client) IterateLabelsContext(context context.Context) *Iterator[Label] {
+	return newIterator[Label](context, api, "labels", url.Values{})
+}
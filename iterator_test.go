@@ -0,0 +1,139 @@
+package todoist
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"reflect"
+	"testing"
+)
+
+func TestIterateActiveTasks(t *testing.T) {
+	http.DefaultServeMux = new(http.ServeMux)
+	http.HandleFunc("/tasks", getTasks)
+	expectedTasks := getTestTasks()
+
+	once.Do(startServer)
+	api := New(validToken, OptionAPIURL("http://"+serverAddr+"/"))
+
+	it := api.IterateActiveTasks(GetActiveTasksRequest{})
+	defer it.Close()
+	var tasks []Task
+	for it.Next() {
+		tasks = append(tasks, it.Value())
+	}
+	if err := it.Err(); err != nil {
+		t.Errorf("Unexpected error: %s", err)
+		return
+	}
+	if !reflect.DeepEqual(expectedTasks, tasks) {
+		t.Fatal(ErrIncorrectResponse)
+	}
+}
+
+func TestIterateProjectsFollowsCursor(t *testing.T) {
+	http.DefaultServeMux = new(http.ServeMux)
+	http.HandleFunc("/projects", getPagedProjects)
+	expectedProjects := append(getTestProjects(), getTestProjects()...)
+
+	once.Do(startServer)
+	api := New(validToken, OptionAPIURL("http://"+serverAddr+"/"))
+
+	it := api.IterateProjects()
+	defer it.Close()
+	var projects []Project
+	for it.Next() {
+		projects = append(projects, it.Value())
+	}
+	if err := it.Err(); err != nil {
+		t.Errorf("Unexpected error: %s", err)
+		return
+	}
+	if !reflect.DeepEqual(expectedProjects, projects) {
+		t.Fatal(ErrIncorrectResponse)
+	}
+}
+
+func TestIteratorStopsEarly(t *testing.T) {
+	http.DefaultServeMux = new(http.ServeMux)
+	http.HandleFunc("/labels", getManyLabels)
+
+	once.Do(startServer)
+	api := New(validToken, OptionAPIURL("http://"+serverAddr+"/"))
+
+	it := api.IterateLabels()
+	if !it.Next() || it.Value().ID != "0" {
+		t.Fatal(ErrIncorrectResponse)
+	}
+	if err := it.Close(); err != nil {
+		t.Errorf("Unexpected error: %s", err)
+	}
+	if it.Next() || it.Err() != nil {
+		t.Fatal("Iterator should be stopped without error")
+	}
+}
+
+func TestIteratorContextCancelled(t *testing.T) {
+	http.DefaultServeMux = new(http.ServeMux)
+	http.HandleFunc("/labels", getManyLabels)
+
+	once.Do(startServer)
+	api := New(validToken, OptionAPIURL("http://"+serverAddr+"/"))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	it := api.IterateLabelsContext(ctx)
+	defer it.Close()
+	it.Next()
+	cancel()
+	for it.Next() {
+	}
+	if !errors.Is(it.Err(), context.Canceled) {
+		t.Fatalf("Expected context.Canceled, got %v", it.Err())
+	}
+}
+
+func TestIterateAllCommentsRequiresId(t *testing.T) {
+	api := New(validToken)
+	it := api.IterateAllCommentsContext("", "", context.Background())
+	if it.Next() || it.Err() == nil {
+		t.Errorf("Succeeded, but should have failed")
+	}
+}
+
+func getPagedProjects(rw http.ResponseWriter, r *http.Request) {
+	var next *string
+	if r.URL.Query().Get("cursor") == "" {
+		cursor := "page-2"
+		next = &cursor
+	}
+	response, _ := json.Marshal(map[string]interface{}{
+		"results":     getTestProjects(),
+		"next_cursor": next,
+	})
+	rw.Header().Set("Content-Type", "application/json")
+	_, err := rw.Write(response)
+	if err != nil {
+		return
+	}
+}
+
+func getManyLabels(rw http.ResponseWriter, _ *http.Request) {
+	rw.Header().Set("Content-Type", "application/json")
+	_, err := rw.Write([]byte("["))
+	if err != nil {
+		return
+	}
+	for i := 0; i < 10000; i++ {
+		separator := ","
+		if i == 0 {
+			separator = ""
+		}
+		_, err := fmt.Fprintf(rw, `%s{"id":"%d","name":"label"}`, separator, i)
+		if err != nil {
+			return
+		}
+	}
+	_, _ = rw.Write([]byte("]"))
+}
@@ -18,18 +18,35 @@ type LabelResponse struct {
 type Label struct {
 	ID         string `json:"id"`
 	Name       string `json:"name"`
-	Color      string `json:"color"`
+	Color      Color  `json:"color"`
 	Order      *int   `json:"order"`
 	IsFavorite bool   `json:"is_favorite"`
+	Extra      Extra  `json:"-"`
 }
 
 type LabelRequest struct {
 	Name       string `json:"name"`        // Required / Optional
-	Color      string `json:"color"`       // Optional
+	Color      Color  `json:"color"`       // Optional
 	Order      *int   `json:"order"`       // Optional
 	IsFavorite *bool  `json:"is_favorite"` // Optional
 }
 
+// Validate checks the request before UpdateLabel sends it. AddLabel also
+// requires Name.
+func (r LabelRequest) Validate() error {
+	return r.validate(false)
+}
+
+func (r LabelRequest) validate(add bool) error {
+	v := newValidator("LabelRequest")
+	if add {
+		v.required("name", r.Name)
+	}
+	v.maxLength("name", r.Name, maxLabelNameLength)
+	validEnum(v, "color", r.Color)
+	return v.err()
+}
+
 func (api *Client) GetLabels() (*[]Label, error) {
 	return api.GetLabelsContext(context.Background())
 }
@@ -79,6 +96,9 @@ func (api *Client) GetSharedLabelsContext(context context.Context) (*[]Label, er
 }
 
 func (api *Client) AddLabelContext(addLabelRequest LabelRequest, context context.Context) (*Label, error) {
+	if err := addLabelRequest.validate(true); err != nil {
+		return nil, err
+	}
 	response := &LabelResponse{}
 
 	request, err := json.Marshal(addLabelRequest)
@@ -93,6 +113,9 @@ func (api *Client) AddLabelContext(addLabelRequest LabelRequest, context context
 }
 
 func (api *Client) UpdateLabelContext(id string, updateLabelRequest LabelRequest, context context.Context) (*Label, error) {
+	if err := updateLabelRequest.Validate(); err != nil {
+		return nil, err
+	}
 	response := &LabelResponse{}
 	request, _ := json.Marshal(updateLabelRequest)
 	err := api.post(context, "labels/"+id, api.token, request, &response.Label)
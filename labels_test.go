@@ -132,7 +132,7 @@ func TestPostLabelById(t *testing.T) {
 
 	api := New(validToken, OptionAPIURL("http://"+serverAddr+"/"))
 	request := UpdateProjectRequest{
-		Name: "name",
+		Name: Set("name"),
 	}
 	project, err := api.UpdateProject("1", request)
 	if err != nil {
@@ -200,7 +200,7 @@ func getTestDeleteLabelByIdResponse() TodoistResponse {
 func getLabels(rw http.ResponseWriter, _ *http.Request) {
 	rw.Header().Set("Content-Type", "application/json")
 	response, _ := json.Marshal(
-		getTestProjects(),
+		getTestLabels(),
 	)
 	_, err := rw.Write(response)
 	if err != nil {
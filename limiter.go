@@ -0,0 +1,212 @@
+package todoist
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+)
+
+const (
+	// Todoist allows 1000 REST requests per user within a 15 minute window.
+	defaultRateLimitRequests = 1000
+	defaultRateLimitPeriod   = 15 * time.Minute
+	defaultRateLimitBurst    = 50
+
+	// After a RateLimitedError the rate is halved, down to minRateFactor of
+	// the configured rate, and every successful request then gives back
+	// recoverRateFactor of it.
+	minRateFactor     = 1.0 / 16
+	recoverRateFactor = 1.0 / 20
+)
+
+// RateLimit configures the client-side token bucket installed by
+// OptionRateLimit: Requests may be sent per Period, with bursts of up to Burst
+// requests.
+type RateLimit struct {
+	Requests int           // Optional, defaults to DefaultRateLimit.Requests
+	Period   time.Duration // Optional, defaults to DefaultRateLimit.Period
+	Burst    int           // Optional, defaults to 1
+}
+
+// DefaultRateLimit matches the per-user quota of the Todoist REST API.
+var DefaultRateLimit = RateLimit{
+	Requests: defaultRateLimitRequests,
+	Period:   defaultRateLimitPeriod,
+	Burst:    defaultRateLimitBurst,
+}
+
+// RateLimiterStats is a snapshot of the client's rate limiter budget.
+type RateLimiterStats struct {
+	Available      float64   // tokens currently in the bucket
+	Burst          int       // bucket capacity
+	Rate           float64   // current requests per second
+	ConfiguredRate float64   // requests per second as configured
+	PausedUntil    time.Time // zero unless a RateLimitedError paused the limiter
+	Waiting        int       // goroutines waiting for a token
+	RateLimited    int       // RateLimitedErrors seen so far
+}
+
+// OptionRateLimit makes every request of the client, from any goroutine,
+// wait for a token of a shared bucket. The rate adapts when the server
+// answers with a RateLimitedError.
+func OptionRateLimit(limit RateLimit) func(*Client) {
+	return func(c *Client) { c.limiter = newRateLimiter(limit.Requests, limit.Period, limit.Burst) }
+}
+
+// RateLimiterStats returns the budget of the limiter installed with
+// OptionRateLimit, and false when there is none.
+func (api *Client) RateLimiterStats() (RateLimiterStats, bool) {
+	if api.limiter == nil {
+		return RateLimiterStats{}, false
+	}
+	return api.limiter.stats(time.Now()), true
+}
+
+// rateLimiter is a token bucket shared by all goroutines using a client.
+type rateLimiter struct {
+	mu          sync.Mutex
+	maxRate     float64 // tokens per second
+	rate        float64
+	burst       float64
+	tokens      float64
+	last        time.Time
+	pausedUntil time.Time
+	waiting     int
+	rateLimited int
+}
+
+func newRateLimiter(requests int, period time.Duration, burst int) *rateLimiter {
+	if requests <= 0 {
+		requests = defaultRateLimitRequests
+	}
+	if period <= 0 {
+		period = defaultRateLimitPeriod
+	}
+	if burst < 1 {
+		burst = 1
+	}
+	rate := float64(requests) / period.Seconds()
+	return &rateLimiter{
+		maxRate: rate,
+		rate:    rate,
+		burst:   float64(burst),
+		tokens:  float64(burst),
+		last:    time.Now(),
+	}
+}
+
+// Wait blocks until a token is available or the context is done.
+func (l *rateLimiter) Wait(ctx context.Context) error {
+	for {
+		delay := l.reserve(time.Now())
+		if delay <= 0 {
+			return nil
+		}
+		timer := time.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			l.done()
+			return ctx.Err()
+		case <-timer.C:
+			l.done()
+		}
+	}
+}
+
+// reserve takes a token and returns 0, or registers a waiter and returns how
+// long to wait before trying again.
+func (l *rateLimiter) reserve(now time.Time) time.Duration {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if now.Before(l.pausedUntil) {
+		l.waiting++
+		return l.pausedUntil.Sub(now)
+	}
+	l.refill(now)
+	if l.tokens >= 1 {
+		l.tokens--
+		return 0
+	}
+	l.waiting++
+	return time.Duration((1 - l.tokens) / l.rate * float64(time.Second))
+}
+
+func (l *rateLimiter) done() {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.waiting--
+}
+
+func (l *rateLimiter) refill(now time.Time) {
+	if now.After(l.last) {
+		l.tokens += now.Sub(l.last).Seconds() * l.rate
+		if l.tokens > l.burst {
+			l.tokens = l.burst
+		}
+	}
+	l.last = now
+}
+
+// observe adapts the limiter to the outcome of a request.
+func (l *rateLimiter) observe(err error) {
+	var rateLimited *RateLimitedError
+	if errors.As(err, &rateLimited) {
+		l.backoff(rateLimited.RetryAfter)
+	} else if err == nil {
+		l.recover()
+	}
+}
+
+// backoff empties the bucket, holds every waiter for d and halves the rate,
+// which is how a RateLimitedError is honoured by all goroutines at once.
+func (l *rateLimiter) backoff(d time.Duration) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := time.Now()
+	l.refill(now)
+	if until := now.Add(d); until.After(l.pausedUntil) {
+		l.pausedUntil = until
+	}
+	l.tokens = 0
+	l.rateLimited++
+	l.rate /= 2
+	if min := l.maxRate * minRateFactor; l.rate < min {
+		l.rate = min
+	}
+}
+
+func (l *rateLimiter) recover() {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.rate < l.maxRate {
+		l.refill(time.Now())
+		l.rate += l.maxRate * recoverRateFactor
+		if l.rate > l.maxRate {
+			l.rate = l.maxRate
+		}
+	}
+}
+
+func (l *rateLimiter) stats(now time.Time) RateLimiterStats {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.refill(now)
+	stats := RateLimiterStats{
+		Available:      l.tokens,
+		Burst:          int(l.burst),
+		Rate:           l.rate,
+		ConfiguredRate: l.maxRate,
+		Waiting:        l.waiting,
+		RateLimited:    l.rateLimited,
+	}
+	if now.Before(l.pausedUntil) {
+		stats.PausedUntil = l.pausedUntil
+	}
+	return stats
+}
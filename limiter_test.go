@@ -0,0 +1,100 @@
+package todoist
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestRateLimiterWait(t *testing.T) {
+	limiter := newRateLimiter(20, time.Second, 1)
+
+	start := time.Now()
+	for i := 0; i < 3; i++ {
+		if err := limiter.Wait(context.Background()); err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+	}
+	if elapsed := time.Since(start); elapsed < 90*time.Millisecond {
+		t.Fatalf("Expected to wait for two tokens, waited %s", elapsed)
+	}
+}
+
+func TestRateLimiterWaitCancelled(t *testing.T) {
+	limiter := newRateLimiter(1, time.Hour, 1)
+	_ = limiter.Wait(context.Background())
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if err := limiter.Wait(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("Expected context.DeadlineExceeded, got %v", err)
+	}
+	if stats := limiter.stats(time.Now()); stats.Waiting != 0 {
+		t.Fatalf("Unexpected waiting count: %d", stats.Waiting)
+	}
+}
+
+func TestRateLimiterDefaults(t *testing.T) {
+	api := New(validToken, OptionRateLimit(RateLimit{}))
+	stats, ok := api.RateLimiterStats()
+	expected := float64(defaultRateLimitRequests) / defaultRateLimitPeriod.Seconds()
+	if !ok || stats.ConfiguredRate != expected || stats.Burst != 1 {
+		t.Fatalf("Unexpected stats %+v", stats)
+	}
+
+	api = New(validToken, OptionRateLimit(RateLimit{Requests: 10}))
+	if stats, _ := api.RateLimiterStats(); stats.ConfiguredRate != 10/defaultRateLimitPeriod.Seconds() {
+		t.Fatalf("Unexpected stats %+v", stats)
+	}
+	api = New(validToken, OptionRateLimit(RateLimit{Period: time.Second, Burst: 5}))
+	if stats, _ := api.RateLimiterStats(); stats.ConfiguredRate != defaultRateLimitRequests || stats.Burst != 5 {
+		t.Fatalf("Unexpected stats %+v", stats)
+	}
+}
+
+func TestOptionRateLimitAdapts(t *testing.T) {
+	http.DefaultServeMux = new(http.ServeMux)
+	limited := true
+	http.HandleFunc("/projects", func(rw http.ResponseWriter, r *http.Request) {
+		if limited {
+			limited = false
+			rw.Header().Set("Retry-After", "0")
+			rw.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		getProjects(rw, r)
+	})
+	once.Do(startServer)
+
+	api := New(validToken,
+		OptionAPIURL("http://"+serverAddr+"/"),
+		OptionRateLimit(RateLimit{Requests: 100, Period: time.Second, Burst: 10}))
+
+	_, err := api.GetProjects()
+	var rateLimited *RateLimitedError
+	if !errors.As(err, &rateLimited) {
+		t.Fatalf("Expected RateLimitedError, got %v", err)
+	}
+	stats, ok := api.RateLimiterStats()
+	if !ok || stats.RateLimited != 1 || stats.Rate != 50 || stats.ConfiguredRate != 100 {
+		t.Fatalf("Unexpected stats: %+v", stats)
+	}
+
+	_, err = api.GetProjects()
+	if err != nil {
+		t.Errorf("Unexpected error: %s", err)
+		return
+	}
+	if stats, _ := api.RateLimiterStats(); stats.Rate != 55 {
+		t.Fatalf("Rate should recover after a success: %+v", stats)
+	}
+}
+
+func TestRateLimiterStatsWithoutLimiter(t *testing.T) {
+	api := New(validToken)
+	if _, ok := api.RateLimiterStats(); ok {
+		t.Fatal("Expected no rate limiter")
+	}
+}
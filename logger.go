@@ -1,19 +1,260 @@
 package todoist
 
-type logger interface {
-	Output(int, string) error
-}
-
-type ilogger interface {
-	logger
-	Print(...interface{})
-	Printf(string, ...interface{})
-	Println(...interface{})
-}
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"regexp"
+	"strings"
+	"time"
+)
 
+// Debug is implemented by the client for code written against the former
+// printf-style logger.
 type Debug interface {
 	Debug() bool
 
 	Debugf(format string, v ...interface{})
 	Debugln(v ...interface{})
 }
+
+// Messages of the events logged by the client.
+const (
+	LogRequestStart  = "todoist request started"
+	LogRequestFinish = "todoist request finished"
+	LogRequestFailed = "todoist request failed"
+	LogRateLimited   = "todoist rate limited"
+	LogRetry         = "todoist request retried"
+	LogDecodeFailed  = "todoist response decoding failed"
+)
+
+// redacted replaces the values of redacted body fields.
+const redacted = "[REDACTED]"
+
+// maxLoggedBody bounds the request and response bodies added to log events.
+const maxLoggedBody = 4096
+
+// defaultRedactedFields are body fields that are redacted even without
+// OptionRedactFields.
+var defaultRedactedFields = []string{"token", "api_token", "access_token", "password"}
+
+var bearerToken = regexp.MustCompile(`Bearer\s+\S+`)
+
+// OptionLogger makes the client log structured events to logger: requests
+// starting and finishing at debug level, failed requests, retries and rate
+// limiting at warn level, and responses that cannot be decoded at error
+// level. Events carry the method, path, status, duration and request id of
+// the request; bodies are only added at debug level, or to failed requests.
+// Bearer tokens are never logged.
+func OptionLogger(logger *slog.Logger) func(*Client) {
+	return func(c *Client) {
+		if logger != nil {
+			c.logger = logger
+		}
+	}
+}
+
+// OptionRedactFields adds JSON fields, at any depth, whose values are replaced
+// by "[REDACTED]" in logged request and response bodies.
+func OptionRedactFields(fields ...string) func(*Client) {
+	return func(c *Client) {
+		for _, field := range fields {
+			c.redactFields[field] = true
+		}
+	}
+}
+
+// Debugf logs a formatted message at debug level.
+//
+// Deprecated: use OptionLogger and log structured events instead.
+func (api *Client) Debugf(format string, v ...interface{}) {
+	api.logger.Debug(fmt.Sprintf(format, v...))
+}
+
+// Debugln logs a message at debug level.
+//
+// Deprecated: use OptionLogger and log structured events instead.
+func (api *Client) Debugln(v ...interface{}) {
+	api.logger.Debug(strings.TrimSuffix(fmt.Sprintln(v...), "\n"))
+}
+
+// Debug reports whether the client's logger records debug events.
+func (api *Client) Debug() bool {
+	return api.logger.Enabled(context.Background(), slog.LevelDebug)
+}
+
+// requestLog collects the attributes shared by the events of one request.
+type requestLog struct {
+	api   *Client
+	ctx   context.Context
+	attrs []slog.Attr
+	start time.Time
+}
+
+func (api *Client) startRequestLog(req *http.Request, operation Operation) *requestLog {
+	l := &requestLog{
+		api: api,
+		ctx: req.Context(),
+		attrs: []slog.Attr{
+			slog.String("operation", operation.Name),
+			slog.String("method", req.Method),
+			slog.String("path", req.URL.Path),
+			slog.String("request_id", req.Header.Get("X-Request-ID")),
+		},
+		start: time.Now(),
+	}
+	if operation.Retry > 0 {
+		l.attrs = append(l.attrs, slog.Int("retry", operation.Retry))
+	}
+
+	if l.enabled(slog.LevelDebug) {
+		attrs := []slog.Attr{}
+		if req.GetBody != nil {
+			if body, err := req.GetBody(); err == nil {
+				content, _ := io.ReadAll(io.LimitReader(body, maxLoggedBody))
+				_ = body.Close()
+				attrs = append(attrs, slog.String("body", api.redactBody(content)))
+			}
+		}
+		l.log(slog.LevelDebug, LogRequestStart, attrs...)
+	}
+	return l
+}
+
+func (l *requestLog) enabled(level slog.Level) bool {
+	return l.api.logger.Enabled(l.ctx, level)
+}
+
+func (l *requestLog) log(level slog.Level, msg string, attrs ...slog.Attr) {
+	l.api.logger.LogAttrs(l.ctx, level, msg, append(attrs, l.attrs...)...)
+}
+
+// finish logs the outcome of sending the request, with the body of a failed
+// response.
+func (l *requestLog) finish(resp *http.Response, err error) {
+	attrs := []slog.Attr{slog.Duration("duration", time.Since(l.start))}
+	if resp != nil {
+		attrs = append(attrs, slog.Int("status", resp.StatusCode))
+	}
+
+	var rateLimited *RateLimitedError
+	switch {
+	case err == nil:
+		if l.enabled(slog.LevelDebug) {
+			l.log(slog.LevelDebug, LogRequestFinish, attrs...)
+		}
+	case errors.As(err, &rateLimited):
+		attrs = append(attrs, slog.Duration("retry_after", rateLimited.RetryAfter))
+		l.log(slog.LevelWarn, LogRateLimited, attrs...)
+	default:
+		attrs = append(attrs, slog.String("error", redactTokens(err.Error())))
+		var statusCode StatusCodeError
+		if errors.As(err, &statusCode) && statusCode.Body != "" {
+			body := []byte(statusCode.Body)
+			if len(body) > maxLoggedBody {
+				body = body[:maxLoggedBody]
+			}
+			attrs = append(attrs, slog.String("body", l.api.redactBody(body)))
+		}
+		l.log(slog.LevelWarn, LogRequestFailed, attrs...)
+	}
+}
+
+func (l *requestLog) decodeFailed(err error) {
+	l.log(slog.LevelError, LogDecodeFailed, slog.String("error", redactTokens(err.Error())))
+}
+
+// logRetry logs that a bulk item is retried after a RateLimitedError.
+func (api *Client) logRetry(ctx context.Context, key string, attempt int, retryAfter time.Duration) {
+	api.logger.LogAttrs(ctx, slog.LevelWarn, LogRetry,
+		slog.String("key", key),
+		slog.Int("retry", attempt),
+		slog.Duration("retry_after", retryAfter))
+}
+
+// redactBody replaces the values of redacted fields of a JSON body, leaving
+// the rest of the body as it was sent. In bodies truncated to maxLoggedBody a
+// redacted value cut off by the end is redacted up to the end. Bodies that are
+// not JSON, such as forms, are replaced by their size.
+func (api *Client) redactBody(body []byte) string {
+	decoder := json.NewDecoder(bytes.NewReader(body))
+	decoder.UseNumber()
+	var spans [][2]int64
+	err := api.redactSpans(decoder, body, &spans)
+	if err == nil {
+		if _, err = decoder.Token(); err == nil {
+			err = errors.New("data after the JSON value")
+		}
+	}
+	if !errors.Is(err, io.EOF) && !errors.Is(err, io.ErrUnexpectedEOF) {
+		return fmt.Sprintf("<%d bytes, not logged>", len(body))
+	}
+
+	var b strings.Builder
+	last := int64(0)
+	for _, span := range spans {
+		b.Write(body[last:span[0]])
+		b.WriteString(`"` + redacted + `"`)
+		last = span[1]
+	}
+	b.Write(body[last:])
+	return redactTokens(b.String())
+}
+
+// redactSpans reads the next value of decoder and collects the offsets of the
+// values of redacted fields in it.
+func (api *Client) redactSpans(decoder *json.Decoder, body []byte, spans *[][2]int64) error {
+	token, err := decoder.Token()
+	if err != nil {
+		return err
+	}
+	delim, ok := token.(json.Delim)
+	if !ok {
+		return nil
+	}
+	for decoder.More() {
+		if delim == '{' {
+			key, err := decoder.Token()
+			if err != nil {
+				return err
+			}
+			if name, _ := key.(string); api.redactFields[name] {
+				var value json.RawMessage
+				if err := decoder.Decode(&value); err != nil {
+					start := decoder.InputOffset()
+					for start < int64(len(body)) && strings.ContainsRune(" \t\r\n:", rune(body[start])) {
+						start++
+					}
+					*spans = append(*spans, [2]int64{start, int64(len(body))})
+					return err
+				}
+				end := decoder.InputOffset()
+				*spans = append(*spans, [2]int64{end - int64(len(value)), end})
+				continue
+			}
+		}
+		if err := api.redactSpans(decoder, body, spans); err != nil {
+			return err
+		}
+	}
+	_, err = decoder.Token()
+	return err
+}
+
+func redactTokens(s string) string {
+	return bearerToken.ReplaceAllString(s, "Bearer "+redacted)
+}
+
+// discardHandler drops every record; it is the handler of clients created
+// without OptionLogger.
+type discardHandler struct{}
+
+func (discardHandler) Enabled(context.Context, slog.Level) bool  { return false }
+func (discardHandler) Handle(context.Context, slog.Record) error { return nil }
+func (h discardHandler) WithAttrs([]slog.Attr) slog.Handler      { return h }
+func (h discardHandler) WithGroup(string) slog.Handler           { return h }
@@ -0,0 +1,127 @@
+package todoist
+
+import (
+	"bytes"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func decodeLogEvents(t *testing.T, buffer *bytes.Buffer) []map[string]interface{} {
+	var events []map[string]interface{}
+	for _, line := range strings.Split(strings.TrimSpace(buffer.String()), "\n") {
+		event := map[string]interface{}{}
+		if err := json.Unmarshal([]byte(line), &event); err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+		events = append(events, event)
+	}
+	return events
+}
+
+func TestLoggerRequestEvents(t *testing.T) {
+	http.DefaultServeMux = new(http.ServeMux)
+	http.HandleFunc("/tasks", func(rw http.ResponseWriter, r *http.Request) {
+		rw.WriteHeader(http.StatusBadRequest)
+		_, _ = rw.Write([]byte(`{"error":"invalid","secret":"hidden","password":"hunter2"}`))
+	})
+	once.Do(startServer)
+
+	buffer := &bytes.Buffer{}
+	api := New(validToken,
+		OptionAPIURL("http://"+serverAddr+"/"),
+		OptionLogger(slog.New(slog.NewJSONHandler(buffer, &slog.HandlerOptions{Level: slog.LevelDebug}))),
+		OptionRedactFields("secret"))
+
+	_, err := api.AddTask(AddTaskRequest{Content: "Buy milk", Description: "token: Bearer abc"})
+	if err == nil {
+		t.Fatal("Expected an error")
+	}
+
+	if strings.Contains(buffer.String(), validToken) || strings.Contains(buffer.String(), "abc") {
+		t.Fatalf("Token logged: %s", buffer.String())
+	}
+	if strings.Contains(buffer.String(), "hidden") || strings.Contains(buffer.String(), "hunter2") {
+		t.Fatalf("Redacted field logged: %s", buffer.String())
+	}
+
+	events := decodeLogEvents(t, buffer)
+	if len(events) != 2 {
+		t.Fatalf("Expected 2 events, got %d", len(events))
+	}
+	start, failed := events[0], events[1]
+	if start["msg"] != LogRequestStart || start["level"] != "DEBUG" {
+		t.Fatal(ErrIncorrectResponse)
+	}
+	if failed["msg"] != LogRequestFailed || failed["level"] != "WARN" {
+		t.Fatal(ErrIncorrectResponse)
+	}
+	for _, event := range events {
+		if event["method"] != http.MethodPost || event["path"] != "/tasks" || event["operation"] != "AddTask" {
+			t.Fatal(ErrIncorrectResponse)
+		}
+		if id, _ := event["request_id"].(string); id == "" {
+			t.Fatal(ErrIncorrectResponse)
+		}
+	}
+	if failed["status"] != float64(http.StatusBadRequest) || failed["duration"] == nil {
+		t.Fatal(ErrIncorrectResponse)
+	}
+	if body, _ := failed["body"].(string); !strings.Contains(body, `"error":"invalid"`) {
+		t.Fatal(ErrIncorrectResponse)
+	}
+}
+
+func TestLoggerRateLimitedAndDecodeFailed(t *testing.T) {
+	http.DefaultServeMux = new(http.ServeMux)
+	http.HandleFunc("/projects", func(rw http.ResponseWriter, r *http.Request) {
+		rw.Header().Set("Retry-After", "3")
+		rw.WriteHeader(http.StatusTooManyRequests)
+	})
+	http.HandleFunc("/labels", func(rw http.ResponseWriter, r *http.Request) {
+		_, _ = rw.Write([]byte(`{"broken"`))
+	})
+	once.Do(startServer)
+
+	buffer := &bytes.Buffer{}
+	api := New(validToken,
+		OptionAPIURL("http://"+serverAddr+"/"),
+		OptionLogger(slog.New(slog.NewJSONHandler(buffer, nil))))
+
+	if _, err := api.GetProjects(); err == nil {
+		t.Fatal("Expected an error")
+	}
+	if _, err := api.GetLabels(); err == nil {
+		t.Fatal("Expected an error")
+	}
+
+	events := decodeLogEvents(t, buffer)
+	if len(events) != 2 {
+		t.Fatalf("Expected 2 events, got %d", len(events))
+	}
+	if events[0]["msg"] != LogRateLimited || events[0]["retry_after"] != float64(3e9) {
+		t.Fatal(ErrIncorrectResponse)
+	}
+	if events[1]["msg"] != LogDecodeFailed || events[1]["level"] != "ERROR" || events[1]["operation"] != "GetLabels" {
+		t.Fatal(ErrIncorrectResponse)
+	}
+}
+
+func TestRedactBodyKeepsLayout(t *testing.T) {
+	api := New(validToken, OptionRedactFields("secret"))
+	for body, expected := range map[string]string{
+		`{"z": 1.50, "secret" : {"nested": [1, 2]}, "list":[{"token":"t\"x"}, 12345678901234567890]}`: `{"z": 1.50, "secret" : "[REDACTED]", "list":[{"token":"[REDACTED]"}, 12345678901234567890]}`,
+		`{"password": "abc", "name": "trunc`: `{"password": "[REDACTED]", "name": "trunc`,
+		`{"b": 1, "token": "ab`:              `{"b": 1, "token": "[REDACTED]"`,
+		`not json`:                           `<8 bytes, not logged>`,
+		`token=abc&name=x`:                   `<16 bytes, not logged>`,
+		`{"a": 1} {"token": "abc"}`:          `<25 bytes, not logged>`,
+		``:                                   ``,
+	} {
+		if redacted := api.redactBody([]byte(body)); redacted != expected {
+			t.Errorf("Unexpected body %s for %s", redacted, body)
+		}
+	}
+}
@@ -1,15 +1,15 @@
 package todoist
 
 import (
+	"context"
 	"fmt"
 	"io"
-	"log"
+	"log/slog"
 	"net/http"
-	"net/http/httputil"
-	"os"
-	"strconv"
 	"strings"
 	"time"
+
+	"github.com/google/uuid"
 )
 
 type responseParser func(*http.Response) error
@@ -20,77 +20,70 @@ type ErrorResponse struct {
 
 func (t ErrorResponse) Error() string { return t.Err }
 
-func checkStatusCode(method string, resp *http.Response, d Debug) error {
-	if resp.StatusCode == http.StatusUnauthorized {
-		return StatusCodeError{Code: resp.StatusCode, Status: resp.Status}
-	}
+func checkStatusCode(req *http.Request, resp *http.Response) error {
 	if resp.StatusCode == http.StatusTooManyRequests {
-		retry, err := strconv.ParseInt(resp.Header.Get("Retry-After"), 10, 64)
-		if err != nil {
-			return err
-		}
-		return &RateLimitedError{time.Duration(retry) * time.Second}
+		err := newStatusCodeError(req, resp)
+		return &RateLimitedError{RetryAfter: err.RetryAfter, Response: err}
 	}
-	if method == http.MethodDelete && resp.StatusCode == http.StatusOK {
-		resp.Status = http.StatusText(http.StatusNoContent)
-		resp.StatusCode = http.StatusNoContent
+	if resp.StatusCode >= http.StatusOK && resp.StatusCode < http.StatusMultipleChoices {
 		return nil
 	}
-	if method == http.MethodPost && ((resp.StatusCode != http.StatusOK) && (resp.StatusCode != http.StatusNoContent)) {
-		err := logResponse(resp, d)
-		if err != nil {
-			return err
-		}
-		return StatusCodeError{Code: resp.StatusCode, Status: resp.Status}
+	return newStatusCodeError(req, resp)
+}
 
-	} else {
-		if resp.StatusCode != http.StatusOK {
-			err := logResponse(resp, d)
-			if err != nil {
-				return err
+func perform(client httpClient, req *http.Request, parser responseParser, api *Client) error {
+	if req.Header.Get("X-Request-ID") == "" {
+		req.Header.Set("X-Request-ID", uuid.New().String())
+	}
+	operation := api.requestOperation(req)
+	req = req.WithContext(context.WithValue(req.Context(), operationKey{}, operation))
+	requestLog := api.startRequestLog(req, operation)
+
+	send := func(req *http.Request) (*http.Response, error) {
+		if api.limiter != nil {
+			if err := api.limiter.Wait(req.Context()); err != nil {
+				return nil, err
 			}
-			return StatusCodeError{Code: resp.StatusCode, Status: resp.Status}
 		}
-	}
-
-	return nil
-}
-
-func perform(client httpClient, req *http.Request, parser responseParser, d Debug) error {
-	resp, err := client.Do(req)
 
-	if err != nil {
-		return err
-	}
-	defer func(Body io.ReadCloser) {
-		err := Body.Close()
+		resp, err := client.Do(req)
 		if err != nil {
-			return
+			return nil, err
+		}
+
+		err = checkStatusCode(req, resp)
+		if api.limiter != nil {
+			api.limiter.observe(err)
 		}
-	}(resp.Body)
+		return resp, err
+	}
 
-	err = checkStatusCode(req.Method, resp, d)
+	resp, err := chainInterceptors(api.interceptors, send)(req)
+	requestLog.finish(resp, err)
+	if resp != nil {
+		defer func(Body io.ReadCloser) {
+			err := Body.Close()
+			if err != nil {
+				return
+			}
+		}(resp.Body)
+	}
 	if err != nil {
 		return err
 	}
 
-	return parser(resp)
-}
-
-func logResponse(resp *http.Response, d Debug) error {
-	if d.Debug() {
-		text, err := httputil.DumpResponse(resp, true)
-		if err != nil {
-			return err
-		}
-		d.Debugln(string(text))
+	if err := parser(resp); err != nil {
+		requestLog.decodeFailed(err)
+		return err
 	}
-
 	return nil
 }
 
+// RateLimitedError is returned for 429 Too Many Requests. RetryAfter is zero
+// when the server did not say how long to wait.
 type RateLimitedError struct {
 	RetryAfter time.Duration
+	Response   StatusCodeError
 }
 
 func (e *RateLimitedError) Error() string {
@@ -101,6 +94,10 @@ func (e *RateLimitedError) Retryable() bool {
 	return true
 }
 
+func (e *RateLimitedError) Unwrap() error {
+	return e.Response
+}
+
 func (t TodoistResponse) Err() error {
 	if t.Ok {
 		return nil
@@ -115,10 +112,15 @@ func (t TodoistResponse) Err() error {
 
 func New(token string, options ...Option) *Client {
 	s := &Client{
-		token:      token,
-		endpoint:   APIURL,
-		httpclient: &http.Client{},
-		log:        log.New(os.Stderr, "volyanyk/todoist", log.LstdFlags|log.Lshortfile),
+		token:        token,
+		endpoint:     APIURL,
+		syncEndpoint: SyncAPIURL,
+		httpclient:   &http.Client{},
+		logger:       slog.New(discardHandler{}),
+		redactFields: map[string]bool{},
+	}
+	for _, field := range defaultRedactedFields {
+		s.redactFields[field] = true
 	}
 
 	for _, opt := range options {
@@ -132,4 +134,14 @@ func OptionAPIURL(u string) func(*Client) {
 	return func(c *Client) { c.endpoint = u }
 }
 
+func OptionSyncAPIURL(u string) func(*Client) {
+	return func(c *Client) { c.syncEndpoint = u }
+}
+
+// OptionHTTPClient sets the HTTP client sending requests, e.g. to share its
+// connections between clients.
+func OptionHTTPClient(client *http.Client) func(*Client) {
+	return func(c *Client) { c.httpclient = client }
+}
+
 type Option func(*Client)
@@ -0,0 +1,169 @@
+package todoist
+
+import (
+	"context"
+	"net/http"
+	"strings"
+)
+
+// Operation describes the API call a request belongs to. The client attaches
+// it to the context of every request it sends, so that interceptors can name
+// and annotate calls without parsing URLs themselves.
+type Operation struct {
+	Name       string // client method, e.g. "GetActiveTasks"
+	Resource   string // e.g. "tasks"
+	ResourceId string // id taken from the path, if any
+	Retry      int    // 0 for the first attempt, incremented by retries
+}
+
+type operationKey struct{}
+type retryKey struct{}
+
+// OperationFromContext returns the operation of a request sent by the client.
+func OperationFromContext(ctx context.Context) (Operation, bool) {
+	operation, ok := ctx.Value(operationKey{}).(Operation)
+	return operation, ok
+}
+
+func withOperationName(ctx context.Context, name string) context.Context {
+	return context.WithValue(ctx, operationKey{}, Operation{Name: name})
+}
+
+func withRetry(ctx context.Context, retry int) context.Context {
+	return context.WithValue(ctx, retryKey{}, retry)
+}
+
+type route struct {
+	method  string
+	pattern string
+	name    string
+}
+
+// routes maps the paths used by the client, relative to the REST or Sync
+// endpoint, to operation names. Literal segments are listed before "{id}"
+// patterns they would otherwise collide with.
+var routes = []route{
+	{http.MethodGet, "tasks", "GetActiveTasks"},
+	{http.MethodPost, "tasks", "AddTask"},
+	{http.MethodGet, "tasks/{id}", "GetActiveTaskById"},
+	{http.MethodPost, "tasks/{id}", "UpdateTask"},
+	{http.MethodDelete, "tasks/{id}", "DeleteTaskById"},
+	{http.MethodPost, "tasks/{id}/close", "CloseTask"},
+	{http.MethodPost, "tasks/{id}/reopen", "ReopenTask"},
+	{http.MethodGet, "projects", "GetProjects"},
+	{http.MethodPost, "projects", "AddProject"},
+	{http.MethodGet, "projects/get_archived", "GetArchivedProjects"},
+	{http.MethodGet, "projects/{id}", "GetProjectById"},
+	{http.MethodPost, "projects/{id}", "UpdateProject"},
+	{http.MethodDelete, "projects/{id}", "DeleteProjectById"},
+	{http.MethodGet, "projects/{id}/collaborators", "GetProjectCollaborators"},
+	{http.MethodGet, "sections", "GetSectionsByProjectId"},
+	{http.MethodPost, "sections", "AddSection"},
+	{http.MethodGet, "sections/{id}", "GetSectionById"},
+	{http.MethodPost, "sections/{id}", "UpdateSection"},
+	{http.MethodDelete, "sections/{id}", "DeleteSectionById"},
+	{http.MethodGet, "sections/{id}/collaborators", "GetSectionCollaborators"},
+	{http.MethodGet, "comments", "GetAllComments"},
+	{http.MethodPost, "comments", "AddComment"},
+	{http.MethodGet, "comments/{id}", "GetCommentById"},
+	{http.MethodPost, "comments/{id}", "UpdateComment"},
+	{http.MethodDelete, "comments/{id}", "DeleteCommentById"},
+	{http.MethodGet, "labels", "GetLabels"},
+	{http.MethodPost, "labels", "AddLabel"},
+	{http.MethodGet, "labels/shared", "GetSharedLabels"},
+	{http.MethodPost, "labels/shared/rename", "RenameLabel"},
+	{http.MethodPost, "labels/shared/remove", "RemoveSharedLabel"},
+	{http.MethodGet, "labels/{id}", "GetLabelById"},
+	{http.MethodPost, "labels/{id}", "UpdateLabel"},
+	{http.MethodDelete, "labels/{id}", "DeleteLabelById"},
+	{http.MethodGet, "archive/sections", "GetArchivedSectionsByProjectId"},
+	{http.MethodGet, "completed/get_all", "GetCompletedTasks"},
+	{http.MethodGet, "completed/get_stats", "GetProductivityStats"},
+	{http.MethodPost, "quick/add", "QuickAddTask"},
+	{http.MethodPost, "sync", "Sync"},
+}
+
+// syncCommandOperations names Sync API calls after the client method that
+// issues their first command.
+var syncCommandOperations = map[string]string{
+	"item_close":           "BulkClose",
+	"item_uncomplete":      "BulkReopen",
+	"item_delete":          "BulkDelete",
+	"item_move":            "MoveTasks",
+	"item_reorder":         "ReorderTasks",
+	"reminder_add":         "AddReminder",
+	"reminder_update":      "UpdateReminder",
+	"reminder_delete":      "DeleteReminder",
+	"filter_add":           "AddFilter",
+	"filter_update":        "UpdateFilter",
+	"filter_delete":        "DeleteFilter",
+	"filter_update_orders": "ReorderFilters",
+	"project_archive":      "ArchiveProject",
+	"project_unarchive":    "UnarchiveProject",
+	"section_archive":      "ArchiveSection",
+	"section_unarchive":    "UnarchiveSection",
+	"share_project":        "ShareProjects",
+	"delete_collaborator":  "DeleteCollaborator",
+	"accept_invitation":    "AcceptInvitation",
+	"reject_invitation":    "RejectInvitation",
+	"delete_invitation":    "DeleteInvitation",
+}
+
+// syncResourceOperations names Sync API reads after the client method reading
+// the resource type.
+var syncResourceOperations = map[string]string{
+	"reminders":     "GetReminders",
+	"filters":       "GetFilters",
+	"collaborators": "GetCollaboratorStates",
+	"user":          "GetUser",
+}
+
+// requestOperation completes the operation attached to the request context,
+// or derives it from the request path.
+func (api *Client) requestOperation(req *http.Request) Operation {
+	operation, _ := OperationFromContext(req.Context())
+	if retry, ok := req.Context().Value(retryKey{}).(int); ok {
+		operation.Retry = retry
+	}
+
+	path := req.URL.Scheme + "://" + req.URL.Host + req.URL.Path
+	if strings.HasPrefix(path, api.endpoint) {
+		path = strings.TrimPrefix(path, api.endpoint)
+	} else {
+		path = strings.TrimPrefix(path, api.syncEndpoint)
+	}
+	segments := strings.Split(strings.Trim(path, "/"), "/")
+	operation.Resource = segments[0]
+
+	for _, r := range routes {
+		if r.method != req.Method {
+			continue
+		}
+		if id, ok := matchRoute(strings.Split(r.pattern, "/"), segments); ok {
+			operation.ResourceId = id
+			if operation.Name == "" {
+				operation.Name = r.name
+			}
+			return operation
+		}
+	}
+	if operation.Name == "" {
+		operation.Name = req.Method + " " + path
+	}
+	return operation
+}
+
+func matchRoute(pattern []string, segments []string) (string, bool) {
+	if len(pattern) != len(segments) {
+		return "", false
+	}
+	id := ""
+	for i, segment := range pattern {
+		if segment == "{id}" {
+			id = segments[i]
+		} else if segment != segments[i] {
+			return "", false
+		}
+	}
+	return id, true
+}
@@ -0,0 +1,124 @@
+package todoist
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+// requestHelpers are the functions sending requests, with the method they use
+// and the index of their path argument.
+var requestHelpers = map[string]struct {
+	method string
+	path   int
+}{
+	"get":                        {http.MethodGet, 1},
+	"syncGet":                    {http.MethodGet, 1},
+	"post":                       {http.MethodPost, 1},
+	"syncPost":                   {http.MethodPost, 1},
+	"performGet":                 {http.MethodGet, 2},
+	"performGetWithParser":       {http.MethodGet, 2},
+	"performPost":                {http.MethodPost, 2},
+	"performPostWithoutResponse": {http.MethodPost, 2},
+	"performDelete":              {http.MethodDelete, 2},
+	"newIterator":                {http.MethodGet, 2},
+}
+
+// parseClientCalls calls fn for every call in the non-test files of the
+// package.
+func parseClientCalls(t *testing.T, fn func(fset *token.FileSet, name string, call *ast.CallExpr)) {
+	fset := token.NewFileSet()
+	packages, err := parser.ParseDir(fset, ".", func(info os.FileInfo) bool {
+		return !strings.HasSuffix(info.Name(), "_test.go")
+	}, 0)
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	for _, file := range packages["todoist"].Files {
+		ast.Inspect(file, func(node ast.Node) bool {
+			call, ok := node.(*ast.CallExpr)
+			if !ok {
+				return true
+			}
+			fun := call.Fun
+			if index, ok := fun.(*ast.IndexExpr); ok {
+				fun = index.X
+			}
+			switch f := fun.(type) {
+			case *ast.Ident:
+				fn(fset, f.Name, call)
+			case *ast.SelectorExpr:
+				fn(fset, f.Sel.Name, call)
+			}
+			return true
+		})
+	}
+}
+
+// pathPattern turns a path expression like api.endpoint+"tasks/"+id into
+// "tasks/{id}". It returns false for paths without any literal part, as
+// passed on by the helpers themselves.
+func pathPattern(expr ast.Expr) (string, bool) {
+	switch e := expr.(type) {
+	case *ast.BinaryExpr:
+		left, leftOk := pathPattern(e.X)
+		right, rightOk := pathPattern(e.Y)
+		return left + right, leftOk || rightOk
+	case *ast.BasicLit:
+		value, _ := strconv.Unquote(e.Value)
+		return value, true
+	case *ast.SelectorExpr:
+		if e.Sel.Name == "endpoint" || e.Sel.Name == "syncEndpoint" {
+			return "", false
+		}
+	}
+	return "{id}", false
+}
+
+func TestRoutesCoverClientPaths(t *testing.T) {
+	parseClientCalls(t, func(fset *token.FileSet, name string, call *ast.CallExpr) {
+		helper, ok := requestHelpers[name]
+		if !ok || len(call.Args) <= helper.path {
+			return
+		}
+		path, ok := pathPattern(call.Args[helper.path])
+		if !ok {
+			return
+		}
+		segments := strings.Split(strings.Trim(path, "/"), "/")
+		for _, r := range routes {
+			if _, ok := matchRoute(strings.Split(r.pattern, "/"), segments); ok && r.method == helper.method {
+				return
+			}
+		}
+		t.Errorf("%s: no route for %s %s", fset.Position(call.Pos()), helper.method, path)
+	})
+}
+
+func TestSyncCallsHaveOperations(t *testing.T) {
+	parseClientCalls(t, func(fset *token.FileSet, name string, call *ast.CallExpr) {
+		switch name {
+		case "newSyncCommand", "newSyncCommandWithTempId":
+			if literal, ok := call.Args[0].(*ast.BasicLit); ok {
+				command, _ := strconv.Unquote(literal.Value)
+				if _, ok := syncCommandOperations[command]; !ok {
+					t.Errorf("%s: no operation for command %s", fset.Position(call.Pos()), command)
+				}
+			}
+		case "readResources":
+			if resources, ok := call.Args[1].(*ast.CompositeLit); ok && len(resources.Elts) > 0 {
+				if literal, ok := resources.Elts[0].(*ast.BasicLit); ok {
+					resource, _ := strconv.Unquote(literal.Value)
+					if _, ok := syncResourceOperations[resource]; !ok {
+						t.Errorf("%s: no operation for resource %s", fset.Position(call.Pos()), resource)
+					}
+				}
+			}
+		}
+	})
+}
@@ -0,0 +1,68 @@
+package todoist
+
+import "encoding/json"
+
+type patchState int
+
+const (
+	patchUnset patchState = iota
+	patchSet
+	patchCleared
+)
+
+// Patch is a field of an update request. The zero value leaves the field
+// unchanged and is not sent; Set changes it to a value and Clear resets it,
+// e.g. removes a task's due date. What clearing sends depends on the field
+// and is documented on the request.
+type Patch[T any] struct {
+	value T
+	state patchState
+}
+
+// Set returns a patch changing a field to value.
+func Set[T any](value T) Patch[T] {
+	return Patch[T]{value: value, state: patchSet}
+}
+
+// Clear returns a patch resetting a field.
+func Clear[T any]() Patch[T] {
+	return Patch[T]{state: patchCleared}
+}
+
+// IsSet reports whether the patch changes the field to a value.
+func (p Patch[T]) IsSet() bool { return p.state == patchSet }
+
+// IsCleared reports whether the patch resets the field.
+func (p Patch[T]) IsCleared() bool { return p.state == patchCleared }
+
+// IsUnset reports whether the patch leaves the field unchanged.
+func (p Patch[T]) IsUnset() bool { return p.state == patchUnset }
+
+// Value returns the value set by the patch, and false unless it is set.
+func (p Patch[T]) Value() (T, bool) {
+	return p.value, p.state == patchSet
+}
+
+// MarshalJSON encodes a set patch as its value and any other one as null.
+// Requests holding patches encode them with omission and clear values of
+// their own.
+func (p Patch[T]) MarshalJSON() ([]byte, error) {
+	if p.state != patchSet {
+		return []byte("null"), nil
+	}
+	return json.Marshal(p.value)
+}
+
+// patchFields is the JSON object of an update request.
+type patchFields map[string]interface{}
+
+// addPatch puts the value of a set patch, or cleared for a cleared one, under key.
+// Unset patches are left out.
+func addPatch[T any](fields patchFields, key string, p Patch[T], cleared interface{}) {
+	switch p.state {
+	case patchSet:
+		fields[key] = p.value
+	case patchCleared:
+		fields[key] = cleared
+	}
+}
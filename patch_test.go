@@ -0,0 +1,78 @@
+package todoist
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"testing"
+)
+
+func TestUpdateTaskRequestMarshal(t *testing.T) {
+	tests := []struct {
+		request  UpdateTaskRequest
+		expected string
+	}{
+		{UpdateTaskRequest{}, `{}`},
+		{UpdateTaskRequest{Content: Set("Buy milk")}, `{"content":"Buy milk"}`},
+		{UpdateTaskRequest{Description: Clear[string](), Labels: Clear[[]string]()}, `{"description":"","labels":[]}`},
+		{UpdateTaskRequest{Labels: Set[[]string](nil)}, `{"labels":[]}`},
+		{UpdateTaskRequest{Priority: Clear[Priority](), AssigneeId: Clear[string]()}, `{"assignee_id":null,"priority":1}`},
+		{UpdateTaskRequest{DueString: Clear[string]()}, `{"due_string":"no date"}`},
+		{UpdateTaskRequest{DueDate: Clear[string](), DueLang: Set("en")}, `{"due_lang":"en","due_string":"no date"}`},
+		{UpdateTaskRequest{DueDate: Set("2023-01-01")}, `{"due_date":"2023-01-01"}`},
+	}
+	for _, test := range tests {
+		content, err := json.Marshal(test.request)
+		if err != nil {
+			t.Errorf("Unexpected error: %s", err)
+			continue
+		}
+		if string(content) != test.expected {
+			t.Errorf("Expected %s, got %s", test.expected, content)
+		}
+	}
+}
+
+func TestUpdateProjectRequestMarshal(t *testing.T) {
+	request := UpdateProjectRequest{Name: Set("Shopping"), Color: Clear[Color](), IsFavorite: Set(true)}
+	content, err := json.Marshal(request)
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	if string(content) != `{"color":"charcoal","is_favorite":true,"name":"Shopping"}` {
+		t.Fatalf("Unexpected body %s", content)
+	}
+}
+
+func TestUpdateTaskSendsTouchedFields(t *testing.T) {
+	http.DefaultServeMux = new(http.ServeMux)
+	var body string
+	http.HandleFunc("/tasks/1", func(rw http.ResponseWriter, r *http.Request) {
+		content, _ := io.ReadAll(r.Body)
+		body = string(content)
+		addTestTaskById("1")(rw, r)
+	})
+	once.Do(startServer)
+	api := New(validToken, OptionAPIURL("http://"+serverAddr+"/"))
+
+	if _, err := api.UpdateTask("1", UpdateTaskRequest{Content: Set("Renamed")}); err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	if body != `{"content":"Renamed"}` {
+		t.Fatalf("Unexpected body %s", body)
+	}
+}
+
+func TestUpdateRequestClearValidation(t *testing.T) {
+	invalid := []interface{ Validate() error }{
+		UpdateTaskRequest{Content: Clear[string]()},
+		UpdateTaskRequest{DueString: Clear[string](), DueDate: Set("2023-01-01")},
+		UpdateTaskRequest{Priority: Set[Priority](0)},
+		UpdateProjectRequest{Name: Clear[string]()},
+	}
+	for _, request := range invalid {
+		if err := request.Validate(); err == nil {
+			t.Errorf("Expected an error for %+v", request)
+		}
+	}
+}
@@ -0,0 +1,272 @@
+package todoist
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+	"sync"
+	"time"
+)
+
+const defaultPoolConcurrency = 8
+
+// PoolOptions configures the clients of a Pool.
+type PoolOptions struct {
+	// HTTPClient is shared by all clients, so that they reuse connections.
+	// Optional, defaults to a client with a pooled transport.
+	HTTPClient *http.Client
+	// RateLimit is applied to every account separately. Optional, defaults to
+	// DefaultRateLimit.
+	RateLimit *RateLimit
+	// Cache returns the cache backend of an account. Optional, accounts are
+	// not cached when nil.
+	Cache    func(account string) CacheBackend
+	CacheTTL time.Duration // Optional, see CacheInterceptor
+	// RefreshToken is called when the server rejects the token of an account
+	// with 401 Unauthorized. The request is retried once with the returned
+	// token, which replaces the account's token. Optional.
+	RefreshToken func(ctx context.Context, account string) (string, error)
+	// OnTokenRotated is called after the token of an account changed, through
+	// RotateToken or RefreshToken. Optional.
+	OnTokenRotated func(account string)
+	// Concurrency bounds the accounts ForEach and PoolMap work on at once.
+	// Optional, defaults to 8.
+	Concurrency int
+	// Options are applied to every client after the ones set by the pool.
+	Options []Option
+}
+
+// Pool manages the clients of many accounts. Each account has its own token,
+// rate limiter and cache, while all of them share the HTTP transport.
+type Pool struct {
+	options    PoolOptions
+	httpclient *http.Client
+
+	mu       sync.RWMutex
+	accounts map[string]*poolAccount
+}
+
+type poolAccount struct {
+	name   string
+	client *Client
+
+	mu    sync.RWMutex
+	token string
+	// refreshing serializes RefreshToken calls of the account.
+	refreshing sync.Mutex
+}
+
+// PoolResult is the outcome of PoolMap for one account.
+type PoolResult[T any] struct {
+	Value T
+	Err   error
+}
+
+var ErrUnknownAccount = errors.New("unknown account")
+
+func NewPool(options PoolOptions) *Pool {
+	httpclient := options.HTTPClient
+	if httpclient == nil {
+		transport := http.DefaultTransport.(*http.Transport).Clone()
+		transport.MaxIdleConnsPerHost = 100
+		httpclient = &http.Client{Transport: transport}
+	}
+	if options.Concurrency <= 0 {
+		options.Concurrency = defaultPoolConcurrency
+	}
+	return &Pool{
+		options:    options,
+		httpclient: httpclient,
+		accounts:   map[string]*poolAccount{},
+	}
+}
+
+// Add creates the client of an account, replacing any previous one.
+func (p *Pool) Add(account string, token string) *Client {
+	a := &poolAccount{name: account, token: token}
+
+	limit := DefaultRateLimit
+	if p.options.RateLimit != nil {
+		limit = *p.options.RateLimit
+	}
+	interceptors := []Interceptor{p.tokenInterceptor(a)}
+	if p.options.Cache != nil {
+		interceptors = append(interceptors, CacheInterceptor(p.options.Cache(account), p.options.CacheTTL))
+	}
+	options := []Option{
+		OptionHTTPClient(p.httpclient),
+		OptionRateLimit(limit),
+		OptionInterceptors(interceptors...),
+	}
+	a.client = New(token, append(options, p.options.Options...)...)
+
+	p.mu.Lock()
+	p.accounts[account] = a
+	p.mu.Unlock()
+	return a.client
+}
+
+// Remove forgets an account.
+func (p *Pool) Remove(account string) {
+	p.mu.Lock()
+	delete(p.accounts, account)
+	p.mu.Unlock()
+}
+
+// Client returns the client of an account.
+func (p *Pool) Client(account string) (*Client, bool) {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	a, ok := p.accounts[account]
+	if !ok {
+		return nil, false
+	}
+	return a.client, true
+}
+
+// Accounts returns the accounts of the pool in sorted order.
+func (p *Pool) Accounts() []string {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	accounts := make([]string, 0, len(p.accounts))
+	for account := range p.accounts {
+		accounts = append(accounts, account)
+	}
+	sort.Strings(accounts)
+	return accounts
+}
+
+// RotateToken replaces the token of an account. Requests sent afterwards use
+// the new token, while the rate limiter and cache of the account are kept.
+func (p *Pool) RotateToken(account string, token string) error {
+	p.mu.RLock()
+	a, ok := p.accounts[account]
+	p.mu.RUnlock()
+	if !ok {
+		return fmt.Errorf("%w: %s", ErrUnknownAccount, account)
+	}
+	p.setToken(a, token)
+	return nil
+}
+
+func (p *Pool) setToken(a *poolAccount, token string) {
+	a.mu.Lock()
+	a.token = token
+	a.mu.Unlock()
+	if p.options.OnTokenRotated != nil {
+		p.options.OnTokenRotated(a.name)
+	}
+}
+
+func (a *poolAccount) currentToken() string {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+	return a.token
+}
+
+// tokenInterceptor authorizes requests with the current token of the account
+// and, with RefreshToken, retries requests rejected with 401 once. It also
+// keys the account's cache entries by account rather than token.
+func (p *Pool) tokenInterceptor(a *poolAccount) Interceptor {
+	return func(req *http.Request, next Handler) (*http.Response, error) {
+		sent := a.currentToken()
+		req = req.WithContext(withCacheAccount(req.Context(), a.name))
+		req.Header.Set("Authorization", "Bearer "+sent)
+		resp, err := next(req)
+		if p.options.RefreshToken == nil || !errors.Is(err, ErrUnauthorized) {
+			return resp, err
+		}
+		if req.Body != nil && req.GetBody == nil {
+			return resp, err
+		}
+
+		token, refreshErr := p.refreshToken(req.Context(), a, sent)
+		if refreshErr != nil {
+			return resp, fmt.Errorf("refreshing token of %s: %w", a.name, refreshErr)
+		}
+		if resp != nil {
+			_ = resp.Body.Close()
+		}
+		retry := req.Clone(req.Context())
+		if req.GetBody != nil {
+			if retry.Body, err = req.GetBody(); err != nil {
+				return nil, err
+			}
+		}
+		retry.Header.Set("Authorization", "Bearer "+token)
+		return next(retry)
+	}
+}
+
+// refreshToken asks for a new token unless another request already replaced
+// the rejected one.
+func (p *Pool) refreshToken(ctx context.Context, a *poolAccount, rejected string) (string, error) {
+	a.refreshing.Lock()
+	defer a.refreshing.Unlock()
+
+	if current := a.currentToken(); current != rejected {
+		return current, nil
+	}
+	token, err := p.options.RefreshToken(ctx, a.name)
+	if err != nil {
+		return "", err
+	}
+	p.setToken(a, token)
+	return token, nil
+}
+
+// ForEach calls fn for every account, at most PoolOptions.Concurrency at
+// once, and returns the errors by account. Accounts that succeeded are not in
+// the map.
+func (p *Pool) ForEach(ctx context.Context, fn func(ctx context.Context, account string, api *Client) error) map[string]error {
+	results := PoolMap(ctx, p, func(ctx context.Context, account string, api *Client) (struct{}, error) {
+		return struct{}{}, fn(ctx, account, api)
+	})
+	errs := map[string]error{}
+	for account, result := range results {
+		if result.Err != nil {
+			errs[account] = result.Err
+		}
+	}
+	return errs
+}
+
+// PoolMap calls fn for every account, at most PoolOptions.Concurrency at
+// once, and collects the results by account. Accounts not started before ctx
+// ended fail with its error.
+func PoolMap[T any](ctx context.Context, p *Pool, fn func(ctx context.Context, account string, api *Client) (T, error)) map[string]PoolResult[T] {
+	p.mu.RLock()
+	accounts := make([]*poolAccount, 0, len(p.accounts))
+	for _, a := range p.accounts {
+		accounts = append(accounts, a)
+	}
+	p.mu.RUnlock()
+
+	var mu sync.Mutex
+	results := make(map[string]PoolResult[T], len(accounts))
+	semaphore := make(chan struct{}, p.options.Concurrency)
+	var wg sync.WaitGroup
+	for _, a := range accounts {
+		select {
+		case semaphore <- struct{}{}:
+		case <-ctx.Done():
+			mu.Lock()
+			results[a.name] = PoolResult[T]{Err: ctx.Err()}
+			mu.Unlock()
+			continue
+		}
+		wg.Add(1)
+		go func(a *poolAccount) {
+			defer wg.Done()
+			defer func() { <-semaphore }()
+			value, err := fn(ctx, a.name, a.client)
+			mu.Lock()
+			results[a.name] = PoolResult[T]{Value: value, Err: err}
+			mu.Unlock()
+		}(a)
+	}
+	wg.Wait()
+	return results
+}
@@ -0,0 +1,131 @@
+package todoist
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"reflect"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestPoolMap(t *testing.T) {
+	http.DefaultServeMux = new(http.ServeMux)
+	http.HandleFunc("/labels", func(rw http.ResponseWriter, r *http.Request) {
+		token := strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer ")
+		if token == "token-c" {
+			rw.WriteHeader(http.StatusForbidden)
+			return
+		}
+		_, _ = rw.Write([]byte(`[{"id":"` + token + `"}]`))
+	})
+	once.Do(startServer)
+
+	pool := NewPool(PoolOptions{Options: []Option{OptionAPIURL("http://" + serverAddr + "/")}})
+	for _, account := range []string{"a", "b", "c"} {
+		pool.Add(account, "token-"+account)
+	}
+	if !reflect.DeepEqual([]string{"a", "b", "c"}, pool.Accounts()) {
+		t.Fatal(ErrIncorrectResponse)
+	}
+
+	results := PoolMap(context.Background(), pool, func(ctx context.Context, account string, api *Client) (string, error) {
+		labels, err := api.GetLabelsContext(ctx)
+		if err != nil {
+			return "", err
+		}
+		return (*labels)[0].ID, nil
+	})
+	if results["a"].Value != "token-a" || results["b"].Value != "token-b" || !errors.Is(results["c"].Err, ErrForbidden) {
+		t.Fatalf("Unexpected results %+v", results)
+	}
+
+	errs := pool.ForEach(context.Background(), func(ctx context.Context, account string, api *Client) error {
+		_, err := api.GetLabelsContext(ctx)
+		return err
+	})
+	if len(errs) != 1 || errs["c"] == nil {
+		t.Fatalf("Unexpected errors %v", errs)
+	}
+
+	a, _ := pool.Client("a")
+	b, _ := pool.Client("b")
+	if a.httpclient != b.httpclient || a.limiter == b.limiter {
+		t.Fatal("Expected a shared transport and separate rate limiters")
+	}
+}
+
+func TestPoolRefreshToken(t *testing.T) {
+	http.DefaultServeMux = new(http.ServeMux)
+	http.HandleFunc("/tasks", func(rw http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer fresh" {
+			rw.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		_, _ = rw.Write([]byte(`{"id":"1","content":"Buy milk"}`))
+	})
+	once.Do(startServer)
+
+	var refreshed, rotated int32
+	pool := NewPool(PoolOptions{
+		Options: []Option{OptionAPIURL("http://" + serverAddr + "/")},
+		RefreshToken: func(ctx context.Context, account string) (string, error) {
+			atomic.AddInt32(&refreshed, 1)
+			return "fresh", nil
+		},
+		OnTokenRotated: func(account string) { atomic.AddInt32(&rotated, 1) },
+	})
+	api := pool.Add("a", "expired")
+
+	for i := 0; i < 2; i++ {
+		task, err := api.AddTask(AddTaskRequest{Content: "Buy milk"})
+		if err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+		if task.Content != "Buy milk" {
+			t.Fatal(ErrIncorrectResponse)
+		}
+	}
+	if atomic.LoadInt32(&refreshed) != 1 || atomic.LoadInt32(&rotated) != 1 {
+		t.Fatalf("Unexpected refreshes %d, rotations %d", refreshed, rotated)
+	}
+
+	if err := pool.RotateToken("missing", "token"); !errors.Is(err, ErrUnknownAccount) {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	pool.Remove("a")
+	if _, ok := pool.Client("a"); ok {
+		t.Fatal("Expected the account to be removed")
+	}
+}
+
+func TestPoolCacheSurvivesRotation(t *testing.T) {
+	http.DefaultServeMux = new(http.ServeMux)
+	var calls int32
+	http.HandleFunc("/labels", func(rw http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		_, _ = rw.Write([]byte(`[{"id":"1"}]`))
+	})
+	once.Do(startServer)
+
+	pool := NewPool(PoolOptions{
+		Options:  []Option{OptionAPIURL("http://" + serverAddr + "/")},
+		Cache:    func(account string) CacheBackend { return NewMemoryCache() },
+		CacheTTL: time.Minute,
+	})
+	api := pool.Add("a", "old")
+	if _, err := api.GetLabels(); err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	if err := pool.RotateToken("a", "new"); err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	if _, err := api.GetLabels(); err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	if atomic.LoadInt32(&calls) != 1 {
+		t.Fatalf("Expected the cache to be kept across the rotation, got %d calls", calls)
+	}
+}
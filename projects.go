@@ -4,6 +4,7 @@ import (
 	"context"
 	"encoding/json"
 	"net/url"
+	"strconv"
 )
 
 type ProjectsResponse struct {
@@ -20,19 +21,65 @@ type CollaboratorsResponse struct {
 }
 
 type Project struct {
-	ID             string  `json:"id"`
-	ParentId       *string `json:"parent_id"`
-	Order          *int    `json:"order"`
-	Color          string  `json:"color"`
-	Name           string  `json:"name"`
-	CommentCount   int     `json:"comment_count"`
-	IsShared       bool    `json:"is_shared"`
-	IsFavorite     bool    `json:"is_favorite"`
-	IsInboxProject bool    `json:"is_inbox_project"`
-	IsTeamInbox    bool    `json:"is_team_inbox"`
-	Url            string  `json:"url"`
-	ViewStyle      string  `json:"view_style"`
+	ID             string    `json:"id"`
+	ParentId       *string   `json:"parent_id"`
+	Order          *int      `json:"order"`
+	Color          Color     `json:"color"`
+	Name           string    `json:"name"`
+	CommentCount   int       `json:"comment_count"`
+	IsShared       bool      `json:"is_shared"`
+	IsFavorite     bool      `json:"is_favorite"`
+	IsInboxProject bool      `json:"is_inbox_project"`
+	IsTeamInbox    bool      `json:"is_team_inbox"`
+	Url            string    `json:"url"`
+	ViewStyle      ViewStyle `json:"view_style"`
+	IsArchived     bool      `json:"is_archived"`
+	Extra          Extra     `json:"-"`
 }
+
+// syncProject is a project as returned by the Sync API, which names some of
+// its fields differently from the REST API.
+type syncProject struct {
+	ID             string    `json:"id"`
+	Name           string    `json:"name"`
+	Color          Color     `json:"color"`
+	ParentId       *string   `json:"parent_id"`
+	ChildOrder     int       `json:"child_order"`
+	Collapsed      bool      `json:"collapsed"`
+	Shared         bool      `json:"shared"`
+	CanAssignTasks bool      `json:"can_assign_tasks"`
+	IsDeleted      bool      `json:"is_deleted"`
+	IsArchived     bool      `json:"is_archived"`
+	IsFavorite     bool      `json:"is_favorite"`
+	SyncId         *string   `json:"sync_id"`
+	InboxProject   bool      `json:"inbox_project"`
+	TeamInbox      bool      `json:"team_inbox"`
+	ViewStyle      ViewStyle `json:"view_style"`
+	CreatedAt      string    `json:"created_at"`
+	UpdatedAt      string    `json:"updated_at"`
+	Extra          Extra     `json:"-"`
+}
+
+// project converts the Sync project to a REST project.
+func (p syncProject) project() Project {
+	order := p.ChildOrder
+	return Project{
+		ID:             p.ID,
+		ParentId:       p.ParentId,
+		Order:          &order,
+		Color:          p.Color,
+		Name:           p.Name,
+		IsShared:       p.Shared,
+		IsFavorite:     p.IsFavorite,
+		IsInboxProject: p.InboxProject,
+		IsTeamInbox:    p.TeamInbox,
+		Url:            "https://todoist.com/showProject?id=" + p.ID,
+		ViewStyle:      p.ViewStyle,
+		IsArchived:     p.IsArchived,
+		Extra:          p.Extra,
+	}
+}
+
 type Collaborator struct {
 	ID    string `json:"id"`
 	Name  string `json:"name"`
@@ -40,17 +87,59 @@ type Collaborator struct {
 }
 
 type AddProjectRequest struct {
-	Name       string  `json:"name"`        // Required
-	ParentId   *string `json:"parent_id"`   // Optional
-	Color      string  `json:"color"`       // Optional
-	IsFavorite *bool   `json:"is_favorite"` // Optional
-	ViewStyle  string  `json:"view_style"`  // Optional
+	Name       string    `json:"name"`        // Required
+	ParentId   *string   `json:"parent_id"`   // Optional
+	Color      Color     `json:"color"`       // Optional
+	IsFavorite *bool     `json:"is_favorite"` // Optional
+	ViewStyle  ViewStyle `json:"view_style"`  // Optional
+}
+type ArchivedProjectsRequest struct {
+	Limit  int // Optional, the server default is used when 0
+	Offset int // Optional
 }
+
+// UpdateProjectRequest changes the fields that are set and leaves the others
+// alone. Clearing Color sends ColorCharcoal, IsFavorite false and ViewStyle
+// ViewStyleList, the defaults of new projects. Name cannot be cleared.
 type UpdateProjectRequest struct {
-	Name       string `json:"name"`        // Required
-	Color      string `json:"color"`       // Optional
-	IsFavorite *bool  `json:"is_favorite"` // Optional
-	ViewStyle  string `json:"view_style"`  // Optional
+	Name       Patch[string]    // Optional
+	Color      Patch[Color]     // Optional
+	IsFavorite Patch[bool]      // Optional
+	ViewStyle  Patch[ViewStyle] // Optional
+}
+
+// Validate checks the request before AddProject sends it.
+func (r AddProjectRequest) Validate() error {
+	v := newValidator("AddProjectRequest")
+	v.required("name", r.Name)
+	v.maxLength("name", r.Name, maxNameLength)
+	validEnum(v, "color", r.Color)
+	validEnum(v, "view_style", r.ViewStyle)
+	return v.err()
+}
+
+// Validate checks the request before UpdateProject sends it.
+func (r UpdateProjectRequest) Validate() error {
+	v := newValidator("UpdateProjectRequest")
+	if r.Name.IsCleared() {
+		v.add("name", "cannot be cleared")
+	}
+	name, _ := r.Name.Value()
+	v.maxLength("name", name, maxNameLength)
+	color, _ := r.Color.Value()
+	validEnum(v, "color", color)
+	viewStyle, _ := r.ViewStyle.Value()
+	validEnum(v, "view_style", viewStyle)
+	return v.err()
+}
+
+func (r UpdateProjectRequest) MarshalJSON() ([]byte, error) {
+	fields := patchFields{}
+	addPatch(fields, "name", r.Name, nil)
+	addPatch(fields, "color", r.Color, defaultColor)
+	addPatch(fields, "is_favorite", r.IsFavorite, false)
+	addPatch(fields, "view_style", r.ViewStyle, defaultViewStyle)
+	return json.Marshal(fields)
 }
 
 func (api *Client) GetProjects() (*[]Project, error) {
@@ -71,6 +160,15 @@ func (api *Client) GetProjectById(id string) (*Project, error) {
 func (api *Client) DeleteProjectById(id string) (*TodoistResponse, error) {
 	return api.DeleteProjectByIdContext(id, context.Background())
 }
+func (api *Client) ArchiveProject(id string) (*TodoistResponse, error) {
+	return api.ArchiveProjectContext(id, context.Background())
+}
+func (api *Client) UnarchiveProject(id string) (*TodoistResponse, error) {
+	return api.UnarchiveProjectContext(id, context.Background())
+}
+func (api *Client) GetArchivedProjects(request ArchivedProjectsRequest) (*[]Project, error) {
+	return api.GetArchivedProjectsContext(request, context.Background())
+}
 
 func (api *Client) GetProjectsContext(context context.Context) (*[]Project, error) {
 	response := &ProjectsResponse{}
@@ -97,6 +195,9 @@ func (api *Client) GetProjectCollaboratorsContext(id string, context context.Con
 }
 
 func (api *Client) AddProjectContext(addProjectRequest AddProjectRequest, context context.Context) (*Project, error) {
+	if err := addProjectRequest.Validate(); err != nil {
+		return nil, err
+	}
 	response := &ProjectResponse{}
 
 	request, err := json.Marshal(addProjectRequest)
@@ -111,6 +212,9 @@ func (api *Client) AddProjectContext(addProjectRequest AddProjectRequest, contex
 }
 
 func (api *Client) UpdateProjectContext(id string, updateProjectRequest UpdateProjectRequest, context context.Context) (*Project, error) {
+	if err := updateProjectRequest.Validate(); err != nil {
+		return nil, err
+	}
 	response := &ProjectResponse{}
 	request, _ := json.Marshal(updateProjectRequest)
 	err := api.post(context, "projects/"+id, api.token, request, &response.Project)
@@ -144,3 +248,45 @@ func (api *Client) DeleteProjectByIdContext(id string, context context.Context)
 
 	return response, err
 }
+func (api *Client) ArchiveProjectContext(id string, context context.Context) (*TodoistResponse, error) {
+	_, err := api.executeCommands(context, newSyncCommand("project_archive", map[string]string{
+		"id": id,
+	}))
+	if err != nil {
+		return nil, err
+	}
+
+	return &TodoistResponse{Ok: true}, nil
+}
+func (api *Client) UnarchiveProjectContext(id string, context context.Context) (*TodoistResponse, error) {
+	_, err := api.executeCommands(context, newSyncCommand("project_unarchive", map[string]string{
+		"id": id,
+	}))
+	if err != nil {
+		return nil, err
+	}
+
+	return &TodoistResponse{Ok: true}, nil
+}
+func (api *Client) GetArchivedProjectsContext(request ArchivedProjectsRequest, context context.Context) (*[]Project, error) {
+	var archived []syncProject
+	values := url.Values{}
+	if request.Limit > 0 {
+		values.Set("limit", strconv.Itoa(request.Limit))
+	}
+	if request.Offset > 0 {
+		values.Set("offset", strconv.Itoa(request.Offset))
+	}
+
+	err := api.syncGet(context,
+		"projects/get_archived",
+		api.token,
+		values,
+		&archived)
+
+	projects := make([]Project, 0, len(archived))
+	for _, project := range archived {
+		projects = append(projects, project.project())
+	}
+	return &projects, err
+}
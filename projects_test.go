@@ -90,7 +90,7 @@ func TestPostProjectById(t *testing.T) {
 
 	api := New(validToken, OptionAPIURL("http://"+serverAddr+"/"))
 	request := UpdateProjectRequest{
-		Name: "name",
+		Name: Set("name"),
 	}
 	project, err := api.UpdateProject("1", request)
 	if err != nil {
@@ -234,3 +234,73 @@ func getDeleteProjectByIdResponse(rw http.ResponseWriter, _ *http.Request) {
 		return
 	}
 }
+
+func TestArchiveProject(t *testing.T) {
+	http.DefaultServeMux = new(http.ServeMux)
+	var commands []SyncCommand
+	http.HandleFunc("/sync", syncCommandsHandler(&commands))
+	once.Do(startServer)
+	expectedResponse := getTestOkResponse()
+
+	api := New(validToken, OptionSyncAPIURL("http://"+serverAddr+"/"))
+	response, err := api.ArchiveProject("1")
+	if err != nil {
+		t.Errorf("Unexpected error: %s", err)
+		return
+	}
+	if !reflect.DeepEqual(expectedResponse, *response) {
+		t.Fatal(ErrIncorrectResponse)
+	}
+	if len(commands) != 1 || commands[0].Type != "project_archive" {
+		t.Fatalf("Unexpected commands: %+v", commands)
+	}
+}
+
+func TestGetArchivedProjects(t *testing.T) {
+	http.DefaultServeMux = new(http.ServeMux)
+	http.HandleFunc("/projects/get_archived", func(rw http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("limit") != "10" || r.URL.Query().Get("offset") != "20" {
+			rw.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		_, _ = rw.Write([]byte(`[{
+			"id": "2203306141",
+			"name": "Old ideas",
+			"color": "lime_green",
+			"parent_id": null,
+			"child_order": 5,
+			"collapsed": false,
+			"shared": true,
+			"can_assign_tasks": true,
+			"is_deleted": false,
+			"is_archived": true,
+			"is_favorite": false,
+			"sync_id": null,
+			"view_style": "board",
+			"created_at": "2023-07-13T10:20:59.000000Z",
+			"updated_at": "2024-01-02T08:00:00.000000Z"
+		}]`))
+	})
+	once.Do(startServer)
+	order := 5
+	expectedProjects := []Project{{
+		ID:         "2203306141",
+		Order:      &order,
+		Color:      ColorLimeGreen,
+		Name:       "Old ideas",
+		IsShared:   true,
+		Url:        "https://todoist.com/showProject?id=2203306141",
+		ViewStyle:  ViewStyleBoard,
+		IsArchived: true,
+	}}
+
+	api := New(validToken, OptionSyncAPIURL("http://"+serverAddr+"/"), OptionStrictDecoding())
+	projects, err := api.GetArchivedProjects(ArchivedProjectsRequest{Limit: 10, Offset: 20})
+	if err != nil {
+		t.Errorf("Unexpected error: %s", err)
+		return
+	}
+	if !reflect.DeepEqual(expectedProjects, *projects) {
+		t.Fatalf("Unexpected projects %+v", *projects)
+	}
+}
@@ -0,0 +1,190 @@
+package todoist
+
+import (
+	"context"
+	"encoding/json"
+	"regexp"
+	"strings"
+)
+
+type QuickAddTaskRequest struct {
+	Text         string `json:"text"`                    // Required
+	Note         string `json:"note,omitempty"`          // Optional
+	Reminder     string `json:"reminder,omitempty"`      // Optional
+	AutoReminder *bool  `json:"auto_reminder,omitempty"` // Optional
+}
+
+// QuickAddPreview is the offline interpretation of a quick add text, as
+// produced by ParseQuickAdd. It is a best-effort preview: the server remains
+// the authority on how the text is finally understood.
+type QuickAddPreview struct {
+	Content  string
+	Project  string
+	Section  string
+	Labels   []string
+	Priority Priority // 0 when not given
+	Due      string
+}
+
+func (api *Client) QuickAddTask(request QuickAddTaskRequest) (*Task, error) {
+	return api.QuickAddTaskContext(request, context.Background())
+}
+
+// QuickAddTaskContext adds a task through the Sync API quick/add endpoint and
+// returns the added item converted to a REST task.
+func (api *Client) QuickAddTaskContext(quickAddTaskRequest QuickAddTaskRequest, context context.Context) (*Task, error) {
+	item := &syncItem{}
+
+	request, err := json.Marshal(quickAddTaskRequest)
+	if err != nil {
+		return nil, err
+	}
+
+	err = api.syncPost(context, "quick/add", api.token, request, item)
+
+	if err != nil {
+		return nil, err
+	} else {
+		task := item.task()
+		return &task, nil
+	}
+}
+
+var (
+	quickAddIsoDate  = regexp.MustCompile(`^\d{4}-\d{1,2}-\d{1,2}$`)
+	quickAddDate     = regexp.MustCompile(`^\d{1,2}[/.]\d{1,2}([/.]\d{2,4})?$`)
+	quickAddTime     = regexp.MustCompile(`^\d{1,2}(:\d{2})?(am|pm)$|^\d{1,2}:\d{2}$`)
+	quickAddOrdinal  = regexp.MustCompile(`^\d{1,2}(st|nd|rd|th)$`)
+	quickAddNumber   = regexp.MustCompile(`^\d+$`)
+	quickAddPriority = regexp.MustCompile(`^[pP][1-4]$`)
+)
+
+var quickAddDateWords = map[string]bool{
+	"today": true, "tod": true, "tomorrow": true, "tmr": true, "tonight": true,
+	"monday": true, "tuesday": true, "wednesday": true, "thursday": true, "friday": true, "saturday": true, "sunday": true,
+	"mon": true, "tue": true, "tues": true, "wed": true, "thu": true, "thur": true, "thurs": true, "fri": true, "sat": true, "sun": true,
+	"january": true, "february": true, "march": true, "april": true, "june": true, "july": true,
+	"august": true, "september": true, "october": true, "november": true, "december": true,
+	"jan": true, "feb": true, "mar": true, "apr": true, "jun": true, "jul": true,
+	"aug": true, "sep": true, "sept": true, "oct": true, "nov": true, "dec": true,
+	"weekday": true, "weekdays": true, "morning": true, "afternoon": true, "evening": true,
+	"noon": true, "midnight": true,
+}
+
+// quickAddUnits only count as a date when quantified, as in "in 3 days" or
+// "every other week", so that "plan the week" keeps its content.
+var quickAddUnits = map[string]bool{
+	"day": true, "days": true, "week": true, "weeks": true, "weekend": true, "month": true, "months": true,
+	"year": true, "years": true, "hour": true, "hours": true, "minute": true, "minutes": true,
+}
+
+// quickAddConnectors may appear inside a due expression but never make one
+// on their own.
+var quickAddConnectors = map[string]bool{
+	"every": true, "next": true, "this": true, "in": true, "at": true, "on": true,
+	"the": true, "of": true, "and": true, "other": true, "starting": true, "from": true, "until": true,
+}
+
+// quickAddTrimmed are connectors that are dropped when they open or close a
+// due expression, e.g. "on friday" previews as "friday".
+var quickAddTrimmed = map[string]bool{
+	"at": true, "on": true, "the": true, "of": true, "and": true, "from": true,
+}
+
+// ParseQuickAdd previews how the quick add endpoint is expected to split text
+// into content, #project, /section, @labels, priority (p1-p4) and a due date,
+// without contacting the server.
+func ParseQuickAdd(text string) QuickAddPreview {
+	preview := QuickAddPreview{}
+	var words []string
+
+	for _, word := range strings.Fields(text) {
+		switch {
+		case len(word) > 1 && word[0] == '#' && preview.Project == "":
+			preview.Project = word[1:]
+		case len(word) > 1 && word[0] == '/' && preview.Section == "":
+			preview.Section = word[1:]
+		case len(word) > 1 && word[0] == '@':
+			if !containsString(preview.Labels, word[1:]) {
+				preview.Labels = append(preview.Labels, word[1:])
+			}
+		case quickAddPriority.MatchString(word) && preview.Priority == 0:
+			preview.Priority, _ = PriorityFromUI(word)
+		default:
+			words = append(words, word)
+		}
+	}
+
+	cut, start, end := findQuickAddDue(words)
+	if start < end {
+		preview.Due = strings.Join(words[start:end], " ")
+		words = append(words[:cut:cut], words[end:]...)
+	}
+	preview.Content = strings.Join(words, " ")
+
+	return preview
+}
+
+// findQuickAddDue returns the bounds of the first run of date words that
+// contains at least one word which is not a mere connector. The run starts at
+// cut, the due expression itself at start once leading connectors are dropped.
+func findQuickAddDue(words []string) (int, int, int) {
+	for i := 0; i < len(words); i++ {
+		j, strong := i, false
+		for ; j < len(words); j++ {
+			kind := quickAddDueWordKind(words[j])
+			if kind == 0 {
+				break
+			}
+			if kind == 3 {
+				strong = strong || (j > i && quickAddQuantifier(words[j-1]))
+				continue
+			}
+			strong = strong || kind == 2
+		}
+		if !strong {
+			i = j
+			continue
+		}
+
+		start, end := i, j
+		for start < end && quickAddTrimmed[strings.ToLower(words[start])] {
+			start++
+		}
+		for end > start && quickAddDueWordKind(words[end-1]) == 1 {
+			end--
+		}
+		return i, start, end
+	}
+	return 0, 0, 0
+}
+
+// quickAddDueWordKind is 0 for words outside of due expressions, 1 for
+// connectors, 2 for words that carry a date or time and 3 for units.
+func quickAddDueWordKind(word string) int {
+	w := strings.ToLower(strings.TrimRight(word, ",."))
+	switch {
+	case quickAddUnits[w]:
+		return 3
+	case quickAddDateWords[w], quickAddIsoDate.MatchString(w), quickAddDate.MatchString(w),
+		quickAddTime.MatchString(w), quickAddOrdinal.MatchString(w):
+		return 2
+	case quickAddConnectors[w], quickAddNumber.MatchString(w):
+		return 1
+	}
+	return 0
+}
+
+func quickAddQuantifier(word string) bool {
+	w := strings.ToLower(word)
+	return quickAddNumber.MatchString(w) || w == "every" || w == "next" || w == "other" || w == "this"
+}
+
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,127 @@
+package todoist
+
+import (
+	"encoding/json"
+	"net/http"
+	"reflect"
+	"testing"
+)
+
+func TestQuickAddTask(t *testing.T) {
+	http.DefaultServeMux = new(http.ServeMux)
+	http.HandleFunc("/quick/add", postQuickAddTask(t))
+	once.Do(startServer)
+	sectionId := "7025"
+	expectedTask := Task{
+		Id:        "1",
+		ProjectId: "2203306141",
+		SectionId: &sectionId,
+		Order:     3,
+		Content:   "Review PR",
+		Labels:    []string{"urgent"},
+		Priority:  PriorityUrgent,
+		CreatorId: "2671355",
+		CreatedAt: "2024-01-08T10:00:00.000000Z",
+		Due:       &Due{Date: "2024-01-09", Datetime: "2024-01-09T17:00:00", String: "tomorrow 5pm"},
+		Url:       "https://todoist.com/showTask?id=1",
+	}
+
+	api := New(validToken, OptionSyncAPIURL("http://"+serverAddr+"/"), OptionStrictDecoding())
+	autoReminder := true
+	request := QuickAddTaskRequest{
+		Text:         "Review PR #Work /Backend @urgent p1 tomorrow 5pm",
+		Note:         "note",
+		AutoReminder: &autoReminder,
+	}
+	task, err := api.QuickAddTask(request)
+	if err != nil {
+		t.Errorf("Unexpected error: %s", err)
+		return
+	}
+	if !reflect.DeepEqual(expectedTask, *task) {
+		t.Fatalf("Unexpected task %+v", *task)
+	}
+}
+
+func TestParseQuickAdd(t *testing.T) {
+	tests := []struct {
+		text     string
+		expected QuickAddPreview
+	}{
+		{
+			text: "Review PR #Work /Backend @urgent p1 tomorrow 5pm",
+			expected: QuickAddPreview{
+				Content:  "Review PR",
+				Project:  "Work",
+				Section:  "Backend",
+				Labels:   []string{"urgent"},
+				Priority: 4,
+				Due:      "tomorrow 5pm",
+			},
+		},
+		{
+			text:     "Pay rent every 1st @home @home",
+			expected: QuickAddPreview{Content: "Pay rent", Labels: []string{"home"}, Due: "every 1st"},
+		},
+		{
+			text:     "Call the office on friday at 9am about taxes p3",
+			expected: QuickAddPreview{Content: "Call the office about taxes", Priority: 2, Due: "friday at 9am"},
+		},
+		{
+			text:     "Submit report in 3 days",
+			expected: QuickAddPreview{Content: "Submit report", Due: "in 3 days"},
+		},
+		{
+			text:     "Plan the week",
+			expected: QuickAddPreview{Content: "Plan the week"},
+		},
+	}
+
+	for _, test := range tests {
+		preview := ParseQuickAdd(test.text)
+		if !reflect.DeepEqual(test.expected, preview) {
+			t.Errorf("ParseQuickAdd(%q) = %+v, expected %+v", test.text, preview, test.expected)
+		}
+	}
+}
+
+func postQuickAddTask(t *testing.T) func(rw http.ResponseWriter, r *http.Request) {
+	response := []byte(`{
+		"id": "1",
+		"user_id": "2671355",
+		"project_id": "2203306141",
+		"content": "Review PR",
+		"description": "",
+		"priority": 4,
+		"due": {"date": "2024-01-09T17:00:00", "timezone": null, "string": "tomorrow 5pm", "lang": "en", "is_recurring": false},
+		"parent_id": null,
+		"child_order": 3,
+		"section_id": "7025",
+		"day_order": -1,
+		"collapsed": false,
+		"labels": ["urgent"],
+		"added_by_uid": "2671355",
+		"assigned_by_uid": null,
+		"responsible_uid": null,
+		"checked": false,
+		"is_deleted": false,
+		"sync_id": null,
+		"completed_at": null,
+		"added_at": "2024-01-08T10:00:00.000000Z"
+	}`)
+
+	return func(rw http.ResponseWriter, r *http.Request) {
+		var request QuickAddTaskRequest
+		if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+			t.Errorf("Unexpected error: %s", err)
+		}
+		if request.Text == "" || request.Note != "note" || request.AutoReminder == nil || !*request.AutoReminder {
+			t.Errorf("Unexpected request: %+v", request)
+		}
+		rw.Header().Set("Content-Type", "application/json")
+		_, err := rw.Write(response)
+		if err != nil {
+			return
+		}
+	}
+}
@@ -0,0 +1,232 @@
+package todoist
+
+import (
+	"context"
+)
+
+type ReminderType string
+
+const (
+	ReminderTypeRelative ReminderType = "relative"
+	ReminderTypeAbsolute ReminderType = "absolute"
+	ReminderTypeLocation ReminderType = "location"
+)
+
+const (
+	LocationTriggerOnEnter = "on_enter"
+	LocationTriggerOnLeave = "on_leave"
+)
+
+type RemindersResponse struct {
+	Reminders []Reminder `json:"reminders"`
+	SyncResponse
+}
+
+type Reminder struct {
+	Id           string       `json:"id"`
+	NotifyUid    string       `json:"notify_uid"`
+	ItemId       string       `json:"item_id"`
+	Type         ReminderType `json:"type"`
+	Due          *Due         `json:"due"`
+	MinuteOffset *int         `json:"minute_offset"`
+	Name         string       `json:"name"`
+	LocLat       string       `json:"loc_lat"`
+	LocLong      string       `json:"loc_long"`
+	LocTrigger   string       `json:"loc_trigger"`
+	Radius       *int         `json:"radius"`
+	IsDeleted    bool         `json:"is_deleted"`
+}
+
+type ReminderDue struct {
+	Date     string `json:"date,omitempty"`
+	String   string `json:"string,omitempty"`
+	Timezone string `json:"timezone,omitempty"`
+	Lang     string `json:"lang,omitempty"`
+}
+
+type ReminderRequest struct {
+	ItemId       string       `json:"item_id,omitempty"`       // Required on add
+	Type         ReminderType `json:"type,omitempty"`          // Required on add
+	NotifyUid    string       `json:"notify_uid,omitempty"`    // Optional
+	Due          *ReminderDue `json:"due,omitempty"`           // Absolute reminders
+	MinuteOffset *int         `json:"minute_offset,omitempty"` // Relative reminders
+	Name         string       `json:"name,omitempty"`          // Location reminders
+	LocLat       string       `json:"loc_lat,omitempty"`       // Location reminders
+	LocLong      string       `json:"loc_long,omitempty"`      // Location reminders
+	LocTrigger   string       `json:"loc_trigger,omitempty"`   // Location reminders
+	Radius       *int         `json:"radius,omitempty"`        // Location reminders, optional
+}
+
+type reminderArgs struct {
+	Id string `json:"id,omitempty"`
+	ReminderRequest
+}
+
+// Validate checks that the fields required by the reminder type are set and
+// that fields belonging to other reminder types are not. AddReminder also
+// requires ItemId.
+func (r ReminderRequest) Validate() error {
+	return r.validate(false)
+}
+
+func (r ReminderRequest) validate(add bool) error {
+	v := newValidator("ReminderRequest")
+	if add {
+		v.required("item_id", r.ItemId)
+	}
+	relative := r.MinuteOffset != nil
+	absolute := r.Due != nil
+	location := r.Name != "" || r.LocLat != "" || r.LocLong != "" || r.LocTrigger != "" || r.Radius != nil
+
+	switch r.Type {
+	case ReminderTypeRelative:
+		if !relative {
+			v.add("minute_offset", "is required for relative reminders")
+		} else if *r.MinuteOffset < 0 {
+			v.add("minute_offset", "must not be negative")
+		}
+		if absolute {
+			v.add("due", "is not accepted by relative reminders")
+		}
+		if location {
+			v.add("name", "location fields are not accepted by relative reminders")
+		}
+	case ReminderTypeAbsolute:
+		if !absolute || (r.Due.Date == "" && r.Due.String == "") {
+			v.add("due", "date or string is required for absolute reminders")
+		}
+		if relative {
+			v.add("minute_offset", "is not accepted by absolute reminders")
+		}
+		if location {
+			v.add("name", "location fields are not accepted by absolute reminders")
+		}
+	case ReminderTypeLocation:
+		v.required("name", r.Name)
+		v.required("loc_lat", r.LocLat)
+		v.required("loc_long", r.LocLong)
+		v.oneOf("loc_trigger", r.LocTrigger, []string{LocationTriggerOnEnter, LocationTriggerOnLeave})
+		if r.LocTrigger == "" {
+			v.add("loc_trigger", "is required for location reminders")
+		}
+		if r.Radius != nil && *r.Radius <= 0 {
+			v.add("radius", "must be positive")
+		}
+		if relative {
+			v.add("minute_offset", "is not accepted by location reminders")
+		}
+		if absolute {
+			v.add("due", "is not accepted by location reminders")
+		}
+	default:
+		v.add("type", "unknown reminder type %q", r.Type)
+	}
+	return v.err()
+}
+
+func (api *Client) GetReminders() (*[]Reminder, error) {
+	return api.GetRemindersContext(context.Background())
+}
+func (api *Client) GetRemindersByTaskId(taskId string) (*[]Reminder, error) {
+	return api.GetRemindersByTaskIdContext(taskId, context.Background())
+}
+func (api *Client) AddReminder(request ReminderRequest) (*Reminder, error) {
+	return api.AddReminderContext(request, context.Background())
+}
+func (api *Client) UpdateReminder(id string, request ReminderRequest) (*TodoistResponse, error) {
+	return api.UpdateReminderContext(id, request, context.Background())
+}
+func (api *Client) DeleteReminder(id string) (*TodoistResponse, error) {
+	return api.DeleteReminderContext(id, context.Background())
+}
+
+func (api *Client) GetRemindersContext(context context.Context) (*[]Reminder, error) {
+	response := &RemindersResponse{}
+
+	err := api.readResources(context, []string{"reminders"}, response)
+	if err != nil {
+		return nil, err
+	}
+
+	reminders := make([]Reminder, 0, len(response.Reminders))
+	for _, reminder := range response.Reminders {
+		if !reminder.IsDeleted {
+			reminders = append(reminders, reminder)
+		}
+	}
+	return &reminders, nil
+}
+
+func (api *Client) GetRemindersByTaskIdContext(taskId string, context context.Context) (*[]Reminder, error) {
+	all, err := api.GetRemindersContext(context)
+	if err != nil {
+		return nil, err
+	}
+
+	reminders := make([]Reminder, 0)
+	for _, reminder := range *all {
+		if reminder.ItemId == taskId {
+			reminders = append(reminders, reminder)
+		}
+	}
+	return &reminders, nil
+}
+
+func (api *Client) AddReminderContext(request ReminderRequest, context context.Context) (*Reminder, error) {
+	if err := request.validate(true); err != nil {
+		return nil, err
+	}
+
+	command := newSyncCommandWithTempId("reminder_add", reminderArgs{ReminderRequest: request})
+	response, err := api.executeCommands(context, command)
+	if err != nil {
+		return nil, err
+	}
+
+	reminder := &Reminder{
+		Id:           response.TempIdMapping[command.TempId],
+		NotifyUid:    request.NotifyUid,
+		ItemId:       request.ItemId,
+		Type:         request.Type,
+		MinuteOffset: request.MinuteOffset,
+		Name:         request.Name,
+		LocLat:       request.LocLat,
+		LocLong:      request.LocLong,
+		LocTrigger:   request.LocTrigger,
+		Radius:       request.Radius,
+	}
+	if request.Due != nil {
+		reminder.Due = &Due{
+			Date:     request.Due.Date,
+			String:   request.Due.String,
+			Timezone: request.Due.Timezone,
+		}
+	}
+	return reminder, nil
+}
+
+func (api *Client) UpdateReminderContext(id string, request ReminderRequest, context context.Context) (*TodoistResponse, error) {
+	if request.Type != "" {
+		if err := request.Validate(); err != nil {
+			return nil, err
+		}
+	}
+
+	_, err := api.executeCommands(context, newSyncCommand("reminder_update", reminderArgs{Id: id, ReminderRequest: request}))
+	if err != nil {
+		return nil, err
+	}
+
+	return &TodoistResponse{Ok: true}, nil
+}
+
+func (api *Client) DeleteReminderContext(id string, context context.Context) (*TodoistResponse, error) {
+	_, err := api.executeCommands(context, newSyncCommand("reminder_delete", map[string]string{
+		"id": id,
+	}))
+	if err != nil {
+		return nil, err
+	}
+
+	return &TodoistResponse{Ok: true}, nil
+}
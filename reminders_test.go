@@ -0,0 +1,117 @@
+package todoist
+
+import (
+	"encoding/json"
+	"net/http"
+	"reflect"
+	"testing"
+)
+
+func TestGetRemindersByTaskId(t *testing.T) {
+	http.DefaultServeMux = new(http.ServeMux)
+	http.HandleFunc("/sync", getReminders)
+	once.Do(startServer)
+	offset := 30
+	expectedReminders := []Reminder{
+		{Id: "1", ItemId: "10", Type: ReminderTypeRelative, MinuteOffset: &offset},
+	}
+
+	api := New(validToken, OptionSyncAPIURL("http://"+serverAddr+"/"))
+	reminders, err := api.GetRemindersByTaskId("10")
+	if err != nil {
+		t.Errorf("Unexpected error: %s", err)
+		return
+	}
+	if !reflect.DeepEqual(expectedReminders, *reminders) {
+		t.Fatal(ErrIncorrectResponse)
+	}
+}
+
+func TestAddReminder(t *testing.T) {
+	http.DefaultServeMux = new(http.ServeMux)
+	var commands []SyncCommand
+	http.HandleFunc("/sync", syncCommandsHandler(&commands))
+	once.Do(startServer)
+
+	api := New(validToken, OptionSyncAPIURL("http://"+serverAddr+"/"))
+	reminder, err := api.AddReminder(ReminderRequest{
+		ItemId: "10",
+		Type:   ReminderTypeAbsolute,
+		Due:    &ReminderDue{Date: "2024-01-02T10:00:00Z"},
+	})
+	if err != nil {
+		t.Errorf("Unexpected error: %s", err)
+		return
+	}
+	if len(commands) != 1 || commands[0].Type != "reminder_add" {
+		t.Fatalf("Unexpected commands: %+v", commands)
+	}
+	if reminder.Id != "id-"+commands[0].TempId || reminder.Due.Date != "2024-01-02T10:00:00Z" {
+		t.Fatal(ErrIncorrectResponse)
+	}
+}
+
+func TestDeleteReminder(t *testing.T) {
+	http.DefaultServeMux = new(http.ServeMux)
+	var commands []SyncCommand
+	http.HandleFunc("/sync", syncCommandsHandler(&commands))
+	once.Do(startServer)
+	expectedResponse := getTestOkResponse()
+
+	api := New(validToken, OptionSyncAPIURL("http://"+serverAddr+"/"))
+	response, err := api.DeleteReminder("1")
+	if err != nil {
+		t.Errorf("Unexpected error: %s", err)
+		return
+	}
+	if !reflect.DeepEqual(expectedResponse, *response) {
+		t.Fatal(ErrIncorrectResponse)
+	}
+	if len(commands) != 1 || string(commands[0].Args.(json.RawMessage)) != `{"id":"1"}` {
+		t.Fatalf("Unexpected commands: %+v", commands)
+	}
+}
+
+func TestReminderRequestValidate(t *testing.T) {
+	offset := 15
+	radius := 100
+	valid := []ReminderRequest{
+		{Type: ReminderTypeRelative, MinuteOffset: &offset},
+		{Type: ReminderTypeAbsolute, Due: &ReminderDue{String: "tomorrow at 9am"}},
+		{Type: ReminderTypeLocation, Name: "Office", LocLat: "1.0", LocLong: "2.0", LocTrigger: LocationTriggerOnEnter, Radius: &radius},
+	}
+	for _, request := range valid {
+		if err := request.Validate(); err != nil {
+			t.Errorf("Unexpected error for %+v: %s", request, err)
+		}
+	}
+
+	invalid := []ReminderRequest{
+		{},
+		{Type: ReminderTypeRelative},
+		{Type: ReminderTypeRelative, MinuteOffset: &offset, Due: &ReminderDue{String: "today"}},
+		{Type: ReminderTypeAbsolute, Due: &ReminderDue{}},
+		{Type: ReminderTypeLocation, Name: "Office", LocLat: "1.0", LocLong: "2.0", LocTrigger: "nearby"},
+	}
+	for _, request := range invalid {
+		if err := request.Validate(); err == nil {
+			t.Errorf("Expected an error for %+v", request)
+		}
+	}
+}
+
+func getReminders(rw http.ResponseWriter, _ *http.Request) {
+	rw.Header().Set("Content-Type", "application/json")
+	_, err := rw.Write([]byte(`{
+		"sync_token": "token",
+		"full_sync": true,
+		"reminders": [
+			{"id": "1", "item_id": "10", "type": "relative", "minute_offset": 30, "is_deleted": false},
+			{"id": "2", "item_id": "10", "type": "relative", "minute_offset": 60, "is_deleted": true},
+			{"id": "3", "item_id": "11", "type": "absolute", "due": {"date": "2024-01-02"}}
+		]
+	}`))
+	if err != nil {
+		return
+	}
+}
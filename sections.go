@@ -10,16 +10,23 @@ type SectionsResponse struct {
 	Sections []Section
 	TodoistResponse
 }
+type archivedSectionsResponse struct {
+	Sections   []Section `json:"sections"`
+	HasMore    bool      `json:"has_more"`
+	NextCursor string    `json:"next_cursor"`
+}
 type SectionResponse struct {
 	Section Section
 	TodoistResponse
 }
 
 type Section struct {
-	ID        string `json:"id"`
-	ProjectId string `json:"project_id"`
-	Order     *int   `json:"order"`
-	Name      string `json:"name"`
+	ID         string `json:"id"`
+	ProjectId  string `json:"project_id"`
+	Order      *int   `json:"order"`
+	Name       string `json:"name"`
+	IsArchived bool   `json:"is_archived"`
+	Extra      Extra  `json:"-"`
 }
 
 type SectionParameters struct {
@@ -28,6 +35,15 @@ type SectionParameters struct {
 	Order     *int   `json:"order"`
 }
 
+// Validate checks the parameters before AddSection sends them.
+func (p SectionParameters) Validate() error {
+	v := newValidator("SectionParameters")
+	v.required("project_id", p.ProjectId)
+	v.required("name", p.Name)
+	v.maxLength("name", p.Name, maxNameLength)
+	return v.err()
+}
+
 func (api *Client) GetSectionsByProjectId(projectId string) (*[]Section, error) {
 	return api.GetSectionsByProjectIdContext(projectId, context.Background())
 }
@@ -43,6 +59,15 @@ func (api *Client) GetSectionById(id string) (*Section, error) {
 func (api *Client) DeleteSectionById(id string) (*TodoistResponse, error) {
 	return api.DeleteSectionByIdContext(id, context.Background())
 }
+func (api *Client) ArchiveSection(id string) (*TodoistResponse, error) {
+	return api.ArchiveSectionContext(id, context.Background())
+}
+func (api *Client) UnarchiveSection(id string) (*TodoistResponse, error) {
+	return api.UnarchiveSectionContext(id, context.Background())
+}
+func (api *Client) GetArchivedSectionsByProjectId(projectId string) (*[]Section, error) {
+	return api.GetArchivedSectionsByProjectIdContext(projectId, context.Background())
+}
 
 func (api *Client) GetSectionsByProjectIdContext(projectId string, context context.Context) (*[]Section, error) {
 	response := &SectionsResponse{}
@@ -71,6 +96,9 @@ func (api *Client) GetSectionCollaboratorsContext(id string, context context.Con
 }
 
 func (api *Client) AddSectionContext(params *SectionParameters, context context.Context) (*Section, error) {
+	if err := params.Validate(); err != nil {
+		return nil, err
+	}
 	response := &SectionResponse{}
 	request, _ := json.Marshal(params)
 	err := api.post(context, "sections", api.token, request, &response.Section)
@@ -120,3 +148,48 @@ func (api *Client) DeleteSectionByIdContext(id string, context context.Context)
 
 	return response, err
 }
+func (api *Client) ArchiveSectionContext(id string, context context.Context) (*TodoistResponse, error) {
+	_, err := api.executeCommands(context, newSyncCommand("section_archive", map[string]string{
+		"id": id,
+	}))
+	if err != nil {
+		return nil, err
+	}
+
+	return &TodoistResponse{Ok: true}, nil
+}
+func (api *Client) UnarchiveSectionContext(id string, context context.Context) (*TodoistResponse, error) {
+	_, err := api.executeCommands(context, newSyncCommand("section_unarchive", map[string]string{
+		"id": id,
+	}))
+	if err != nil {
+		return nil, err
+	}
+
+	return &TodoistResponse{Ok: true}, nil
+}
+
+// GetArchivedSectionsByProjectIdContext pages through the archived sections
+// of a project and returns all of them.
+func (api *Client) GetArchivedSectionsByProjectIdContext(projectId string, context context.Context) (*[]Section, error) {
+	sections := []Section{}
+	values := url.Values{
+		"project_id": {projectId},
+	}
+	for {
+		response := &archivedSectionsResponse{}
+		err := api.syncGet(context,
+			"archive/sections",
+			api.token,
+			values,
+			response)
+		if err != nil {
+			return &sections, err
+		}
+		sections = append(sections, response.Sections...)
+		if !response.HasMore || response.NextCursor == "" {
+			return &sections, nil
+		}
+		values.Set("cursor", response.NextCursor)
+	}
+}
@@ -201,3 +201,55 @@ func updateSectionById(id string) func(http.ResponseWriter, *http.Request) {
 		}
 	}
 }
+
+func TestArchiveSection(t *testing.T) {
+	http.DefaultServeMux = new(http.ServeMux)
+	var commands []SyncCommand
+	http.HandleFunc("/sync", syncCommandsHandler(&commands))
+	once.Do(startServer)
+
+	api := New(validToken, OptionSyncAPIURL("http://"+serverAddr+"/"))
+	_, err := api.ArchiveSection("1")
+	if err != nil {
+		t.Errorf("Unexpected error: %s", err)
+		return
+	}
+	_, err = api.UnarchiveSection("1")
+	if err != nil {
+		t.Errorf("Unexpected error: %s", err)
+		return
+	}
+	if len(commands) != 2 || commands[0].Type != "section_archive" || commands[1].Type != "section_unarchive" {
+		t.Fatalf("Unexpected commands: %+v", commands)
+	}
+}
+
+func TestGetArchivedSectionsByProjectId(t *testing.T) {
+	http.DefaultServeMux = new(http.ServeMux)
+	http.HandleFunc("/archive/sections", func(rw http.ResponseWriter, r *http.Request) {
+		sections := getTestSectionsByProjectId("1")
+		page := map[string]interface{}{"sections": sections[:1], "has_more": true, "next_cursor": "next"}
+		if r.URL.Query().Get("cursor") == "next" {
+			page = map[string]interface{}{"sections": sections[1:], "has_more": false, "next_cursor": nil}
+		}
+		if r.URL.Query().Get("project_id") != "1" {
+			rw.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		response, _ := json.Marshal(page)
+		_, _ = rw.Write(response)
+	})
+	expectedSections := getTestSectionsByProjectId("1")
+
+	once.Do(startServer)
+	api := New(validToken, OptionSyncAPIURL("http://"+serverAddr+"/"))
+
+	sections, err := api.GetArchivedSectionsByProjectId("1")
+	if err != nil {
+		t.Errorf("Unexpected error: %s", err)
+		return
+	}
+	if !reflect.DeepEqual(expectedSections, *sections) {
+		t.Fatal(ErrIncorrectResponse)
+	}
+}
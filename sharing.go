@@ -0,0 +1,222 @@
+package todoist
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+type CollaboratorRole string
+
+const (
+	CollaboratorRoleCreator        CollaboratorRole = "CREATOR"
+	CollaboratorRoleAdmin          CollaboratorRole = "ADMIN"
+	CollaboratorRoleReadWrite      CollaboratorRole = "READ_WRITE"
+	CollaboratorRoleReadAndComment CollaboratorRole = "READ_AND_COMMENT"
+	CollaboratorRoleReadOnly       CollaboratorRole = "READ_ONLY"
+)
+
+type CollaboratorStatesResponse struct {
+	Collaborators      []Collaborator      `json:"collaborators"`
+	CollaboratorStates []CollaboratorState `json:"collaborator_states"`
+	SyncResponse
+}
+
+// syncCollaborator is a collaborator as returned by the Sync API, which has
+// its full_name where the REST API has name.
+type syncCollaborator struct {
+	ID       string  `json:"id"`
+	Email    string  `json:"email"`
+	FullName string  `json:"full_name"`
+	Timezone string  `json:"timezone"`
+	ImageId  *string `json:"image_id"`
+}
+
+func (c syncCollaborator) collaborator() Collaborator {
+	return Collaborator{ID: c.ID, Name: c.FullName, Email: c.Email}
+}
+
+type CollaboratorState struct {
+	ProjectId string           `json:"project_id"`
+	UserId    string           `json:"user_id"`
+	State     string           `json:"state"`
+	Role      CollaboratorRole `json:"role"`
+	IsDeleted bool             `json:"is_deleted"`
+}
+
+type ShareProjectRequest struct {
+	ProjectId string           `json:"project_id"`     // Required
+	Email     string           `json:"email"`          // Required
+	Role      CollaboratorRole `json:"role,omitempty"` // Optional, workspace projects only
+}
+
+func (api *Client) ShareProject(request ShareProjectRequest) (*TodoistResponse, error) {
+	return api.ShareProjectContext(request, context.Background())
+}
+func (api *Client) ShareProjects(requests []ShareProjectRequest) (*TodoistResponse, error) {
+	return api.ShareProjectsContext(requests, context.Background())
+}
+func (api *Client) UpdateCollaboratorRole(projectId string, email string, role CollaboratorRole) (*TodoistResponse, error) {
+	return api.UpdateCollaboratorRoleContext(projectId, email, role, context.Background())
+}
+func (api *Client) DeleteCollaborator(projectId string, email string) (*TodoistResponse, error) {
+	return api.DeleteCollaboratorContext(projectId, email, context.Background())
+}
+func (api *Client) AcceptInvitation(id string, secret string) (*TodoistResponse, error) {
+	return api.AcceptInvitationContext(id, secret, context.Background())
+}
+func (api *Client) RejectInvitation(id string, secret string) (*TodoistResponse, error) {
+	return api.RejectInvitationContext(id, secret, context.Background())
+}
+func (api *Client) DeleteInvitation(id string) (*TodoistResponse, error) {
+	return api.DeleteInvitationContext(id, context.Background())
+}
+func (api *Client) GetCollaboratorStates() (*CollaboratorStatesResponse, error) {
+	return api.GetCollaboratorStatesContext(context.Background())
+}
+func (api *Client) AssignTaskByEmail(taskId string, email string) (*Task, error) {
+	return api.AssignTaskByEmailContext(taskId, email, context.Background())
+}
+
+func (api *Client) ShareProjectContext(request ShareProjectRequest, context context.Context) (*TodoistResponse, error) {
+	return api.ShareProjectsContext([]ShareProjectRequest{request}, context)
+}
+
+// ShareProjectsContext shares every project of the requests in as few Sync API
+// calls as possible, which is how a new member is onboarded to many projects.
+func (api *Client) ShareProjectsContext(requests []ShareProjectRequest, context context.Context) (*TodoistResponse, error) {
+	commands := make([]SyncCommand, 0, len(requests))
+	for _, request := range requests {
+		if request.ProjectId == "" || request.Email == "" {
+			return nil, fmt.Errorf("project_id and email are required to share a project")
+		}
+		commands = append(commands, newSyncCommand("share_project", request))
+	}
+
+	_, err := api.executeCommands(context, commands...)
+	if err != nil {
+		return nil, err
+	}
+
+	return &TodoistResponse{Ok: true}, nil
+}
+
+// UpdateCollaboratorRoleContext changes the role of a collaborator of a
+// workspace project. The Sync API sets roles through the share_project
+// command, which is sent again with the new role.
+func (api *Client) UpdateCollaboratorRoleContext(projectId string, email string, role CollaboratorRole, context context.Context) (*TodoistResponse, error) {
+	if projectId == "" || email == "" {
+		return nil, fmt.Errorf("project_id and email are required to update a collaborator role")
+	}
+	switch role {
+	case CollaboratorRoleAdmin, CollaboratorRoleReadWrite, CollaboratorRoleReadAndComment, CollaboratorRoleReadOnly:
+	default:
+		return nil, fmt.Errorf("invalid collaborator role %q", role)
+	}
+
+	_, err := api.executeCommands(withOperationName(context, "UpdateCollaboratorRole"), newSyncCommand("share_project", ShareProjectRequest{
+		ProjectId: projectId,
+		Email:     email,
+		Role:      role,
+	}))
+	if err != nil {
+		return nil, err
+	}
+
+	return &TodoistResponse{Ok: true}, nil
+}
+
+func (api *Client) DeleteCollaboratorContext(projectId string, email string, context context.Context) (*TodoistResponse, error) {
+	_, err := api.executeCommands(context, newSyncCommand("delete_collaborator", map[string]string{
+		"project_id": projectId,
+		"email":      email,
+	}))
+	if err != nil {
+		return nil, err
+	}
+
+	return &TodoistResponse{Ok: true}, nil
+}
+
+func (api *Client) AcceptInvitationContext(id string, secret string, context context.Context) (*TodoistResponse, error) {
+	_, err := api.executeCommands(context, newSyncCommand("accept_invitation", map[string]string{
+		"invitation_id":     id,
+		"invitation_secret": secret,
+	}))
+	if err != nil {
+		return nil, err
+	}
+
+	return &TodoistResponse{Ok: true}, nil
+}
+
+func (api *Client) RejectInvitationContext(id string, secret string, context context.Context) (*TodoistResponse, error) {
+	_, err := api.executeCommands(context, newSyncCommand("reject_invitation", map[string]string{
+		"invitation_id":     id,
+		"invitation_secret": secret,
+	}))
+	if err != nil {
+		return nil, err
+	}
+
+	return &TodoistResponse{Ok: true}, nil
+}
+
+func (api *Client) DeleteInvitationContext(id string, context context.Context) (*TodoistResponse, error) {
+	_, err := api.executeCommands(context, newSyncCommand("delete_invitation", map[string]string{
+		"invitation_id": id,
+	}))
+	if err != nil {
+		return nil, err
+	}
+
+	return &TodoistResponse{Ok: true}, nil
+}
+
+func (api *Client) GetCollaboratorStatesContext(context context.Context) (*CollaboratorStatesResponse, error) {
+	synced := &struct {
+		Collaborators      []syncCollaborator  `json:"collaborators"`
+		CollaboratorStates []CollaboratorState `json:"collaborator_states"`
+		SyncResponse
+	}{}
+
+	err := api.readResources(context, []string{"collaborators"}, synced)
+	if err != nil {
+		return nil, err
+	}
+
+	response := &CollaboratorStatesResponse{
+		CollaboratorStates: synced.CollaboratorStates,
+		SyncResponse:       synced.SyncResponse,
+	}
+	for _, collaborator := range synced.Collaborators {
+		response.Collaborators = append(response.Collaborators, collaborator.collaborator())
+	}
+	return response, nil
+}
+
+// AssignTaskByEmailContext resolves the email against the collaborators of the
+// task's project and assigns the task to the matching collaborator.
+func (api *Client) AssignTaskByEmailContext(taskId string, email string, context context.Context) (*Task, error) {
+	task, err := api.GetActiveTaskByIdContext(taskId, context)
+	if err != nil {
+		return nil, err
+	}
+	collaborators, err := api.GetProjectCollaboratorsContext(task.ProjectId, context)
+	if err != nil {
+		return nil, err
+	}
+
+	assigneeId := ""
+	for _, collaborator := range *collaborators {
+		if strings.EqualFold(collaborator.Email, email) {
+			assigneeId = collaborator.ID
+			break
+		}
+	}
+	if assigneeId == "" {
+		return nil, fmt.Errorf("no collaborator with email %q in project %s", email, task.ProjectId)
+	}
+
+	return api.UpdateTaskContext(taskId, UpdateTaskRequest{AssigneeId: Set(assigneeId)}, context)
+}
@@ -0,0 +1,141 @@
+package todoist
+
+import (
+	"encoding/json"
+	"net/http"
+	"reflect"
+	"testing"
+)
+
+func TestShareProjects(t *testing.T) {
+	http.DefaultServeMux = new(http.ServeMux)
+	var commands []SyncCommand
+	http.HandleFunc("/sync", syncCommandsHandler(&commands))
+	once.Do(startServer)
+	expectedResponse := getTestOkResponse()
+
+	api := New(validToken, OptionSyncAPIURL("http://"+serverAddr+"/"))
+	response, err := api.ShareProjects([]ShareProjectRequest{
+		{ProjectId: "1", Email: "new@example.com"},
+		{ProjectId: "2", Email: "new@example.com", Role: CollaboratorRoleReadWrite},
+	})
+	if err != nil {
+		t.Errorf("Unexpected error: %s", err)
+		return
+	}
+	if !reflect.DeepEqual(expectedResponse, *response) {
+		t.Fatal(ErrIncorrectResponse)
+	}
+	if len(commands) != 2 || commands[0].Type != "share_project" ||
+		string(commands[1].Args.(json.RawMessage)) != `{"project_id":"2","email":"new@example.com","role":"READ_WRITE"}` {
+		t.Fatalf("Unexpected commands: %+v", commands)
+	}
+}
+
+func TestAcceptInvitation(t *testing.T) {
+	http.DefaultServeMux = new(http.ServeMux)
+	var commands []SyncCommand
+	http.HandleFunc("/sync", syncCommandsHandler(&commands))
+	once.Do(startServer)
+
+	api := New(validToken, OptionSyncAPIURL("http://"+serverAddr+"/"))
+	_, err := api.AcceptInvitation("1", "secret")
+	if err != nil {
+		t.Errorf("Unexpected error: %s", err)
+		return
+	}
+	if len(commands) != 1 || commands[0].Type != "accept_invitation" ||
+		string(commands[0].Args.(json.RawMessage)) != `{"invitation_id":"1","invitation_secret":"secret"}` {
+		t.Fatalf("Unexpected commands: %+v", commands)
+	}
+}
+
+func TestUpdateCollaboratorRole(t *testing.T) {
+	http.DefaultServeMux = new(http.ServeMux)
+	var commands []SyncCommand
+	var operations []string
+	http.HandleFunc("/sync", syncCommandsHandler(&commands))
+	once.Do(startServer)
+
+	api := New(validToken, OptionSyncAPIURL("http://"+serverAddr+"/"),
+		OptionInterceptors(RequestInterceptor(func(req *http.Request) error {
+			operation, _ := OperationFromContext(req.Context())
+			operations = append(operations, operation.Name)
+			return nil
+		})))
+	_, err := api.UpdateCollaboratorRole("1", "dev@example.com", CollaboratorRoleReadOnly)
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	if len(commands) != 1 || commands[0].Type != "share_project" ||
+		string(commands[0].Args.(json.RawMessage)) != `{"project_id":"1","email":"dev@example.com","role":"READ_ONLY"}` {
+		t.Fatalf("Unexpected commands: %+v", commands)
+	}
+	if !reflect.DeepEqual(operations, []string{"UpdateCollaboratorRole"}) {
+		t.Fatalf("Unexpected operations: %v", operations)
+	}
+
+	if _, err := api.UpdateCollaboratorRole("1", "dev@example.com", CollaboratorRoleCreator); err == nil {
+		t.Fatal("Expected an error for the creator role")
+	}
+}
+
+func TestGetCollaboratorStates(t *testing.T) {
+	http.DefaultServeMux = new(http.ServeMux)
+	http.HandleFunc("/sync", func(rw http.ResponseWriter, r *http.Request) {
+		_, _ = rw.Write([]byte(`{
+			"sync_token": "token",
+			"full_sync": true,
+			"collaborators": [
+				{"id": "2671362", "email": "ana@example.com", "full_name": "Ana", "timezone": "Europe/Madrid", "image_id": null}
+			],
+			"collaborator_states": [
+				{"project_id": "2203306141", "user_id": "2671362", "state": "active", "role": "READ_WRITE", "is_deleted": false}
+			]
+		}`))
+	})
+	once.Do(startServer)
+
+	api := New(validToken, OptionSyncAPIURL("http://"+serverAddr+"/"))
+	states, err := api.GetCollaboratorStates()
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	expectedCollaborators := []Collaborator{{ID: "2671362", Name: "Ana", Email: "ana@example.com"}}
+	if !reflect.DeepEqual(expectedCollaborators, states.Collaborators) || len(states.CollaboratorStates) != 1 ||
+		states.CollaboratorStates[0].Role != CollaboratorRoleReadWrite || states.SyncToken != "token" {
+		t.Fatalf("Unexpected states %+v", states)
+	}
+}
+
+func TestAssignTaskByEmail(t *testing.T) {
+	http.DefaultServeMux = new(http.ServeMux)
+	task := getTestTaskWithId("1")
+	task.ProjectId = "1"
+	http.HandleFunc("/projects/1/collaborators", getProjectCollaborators)
+	http.HandleFunc("/tasks/1", func(rw http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodPost {
+			request := map[string]string{}
+			_ = json.NewDecoder(r.Body).Decode(&request)
+			if !reflect.DeepEqual(map[string]string{"assignee_id": "12345"}, request) {
+				rw.WriteHeader(http.StatusBadRequest)
+				return
+			}
+			assigneeID := request["assignee_id"]
+			task.AssigneeId = &assigneeID
+		}
+		response, _ := json.Marshal(task)
+		_, _ = rw.Write(response)
+	})
+	once.Do(startServer)
+
+	api := New(validToken, OptionAPIURL("http://"+serverAddr+"/"))
+	updated, err := api.AssignTaskByEmail("1", "TEST EMAIL")
+	if err != nil {
+		t.Errorf("Unexpected error: %s", err)
+		return
+	}
+	if updated.AssigneeId == nil || *updated.AssigneeId != "12345" {
+		t.Fatal(ErrIncorrectResponse)
+	}
+}
@@ -1,17 +1,58 @@
 package todoist
 
 import (
+	"bytes"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
 	"net/http"
+	"strconv"
+	"strings"
+	"time"
 )
 
+// maxErrorBody bounds the part of an error response kept in StatusCodeError.
+const maxErrorBody = 64 << 10
+
+var (
+	ErrUnauthorized = errors.New("unauthorized")
+	ErrForbidden    = errors.New("forbidden")
+	ErrNotFound     = errors.New("not found")
+)
+
+// StatusCodeError is returned when the server answers with an unexpected
+// status code. Use errors.Is with ErrUnauthorized, ErrForbidden or
+// ErrNotFound to test for common codes.
 type StatusCodeError struct {
-	Code   int
-	Status string
+	Code       int
+	Status     string
+	Method     string
+	Path       string
+	RequestID  string // X-Request-ID sent with the request
+	Body       string
+	Message    string // error message parsed from Body
+	ErrorCode  int    // Sync API error code, if any
+	ErrorTag   string // Sync API error tag, if any
+	RetryAfter time.Duration
 }
 
 func (t StatusCodeError) Error() string {
-	return fmt.Sprintf("server error: %s", t.Status)
+	message := fmt.Sprintf("server error: %s", t.Status)
+	if t.Message != "" {
+		message += ": " + t.Message
+	}
+	if t.ErrorTag != "" {
+		message += fmt.Sprintf(" (%s)", t.ErrorTag)
+	}
+	if t.Method != "" {
+		message += fmt.Sprintf(" [%s %s", t.Method, t.Path)
+		if t.RequestID != "" {
+			message += ", request id " + t.RequestID
+		}
+		message += "]"
+	}
+	return message
 }
 
 func (t StatusCodeError) HTTPStatusCode() int {
@@ -24,3 +65,71 @@ func (t StatusCodeError) Retryable() bool {
 	}
 	return false
 }
+
+func (t StatusCodeError) Is(target error) bool {
+	switch target {
+	case ErrUnauthorized:
+		return t.Code == http.StatusUnauthorized
+	case ErrForbidden:
+		return t.Code == http.StatusForbidden
+	case ErrNotFound:
+		return t.Code == http.StatusNotFound
+	}
+	return false
+}
+
+// syncErrorBody is the error format of the Sync API. The REST API answers
+// with plain text instead.
+type syncErrorBody struct {
+	Error     string `json:"error"`
+	ErrorCode int    `json:"error_code"`
+	ErrorTag  string `json:"error_tag"`
+}
+
+// newStatusCodeError describes an unexpected response. The body is read and
+// replaced, so that it can still be read by interceptors.
+func newStatusCodeError(req *http.Request, resp *http.Response) StatusCodeError {
+	err := StatusCodeError{
+		Code:       resp.StatusCode,
+		Status:     resp.Status,
+		Method:     req.Method,
+		Path:       req.URL.Path,
+		RequestID:  req.Header.Get("X-Request-ID"),
+		RetryAfter: parseRetryAfter(resp.Header.Get("Retry-After")),
+	}
+	if resp.Body == nil {
+		return err
+	}
+
+	content, _ := io.ReadAll(io.LimitReader(resp.Body, maxErrorBody))
+	_ = resp.Body.Close()
+	resp.Body = io.NopCloser(bytes.NewReader(content))
+
+	err.Body = string(content)
+	body := syncErrorBody{}
+	if json.Unmarshal(content, &body) == nil && body.Error != "" {
+		err.Message = body.Error
+		err.ErrorCode = body.ErrorCode
+		err.ErrorTag = body.ErrorTag
+	} else if !bytes.HasPrefix(bytes.TrimSpace(content), []byte("<")) {
+		err.Message = strings.TrimSpace(err.Body)
+	}
+	return err
+}
+
+// parseRetryAfter accepts both forms of the Retry-After header, and returns 0
+// when it is missing or invalid.
+func parseRetryAfter(value string) time.Duration {
+	if value == "" {
+		return 0
+	}
+	if seconds, err := strconv.ParseInt(value, 10, 64); err == nil {
+		return time.Duration(seconds) * time.Second
+	}
+	if date, err := http.ParseTime(value); err == nil {
+		if d := time.Until(date); d > 0 {
+			return d
+		}
+	}
+	return 0
+}
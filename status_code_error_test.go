@@ -0,0 +1,137 @@
+package todoist
+
+import (
+	"errors"
+	"net/http"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestStatusCodeErrorFromRestResponse(t *testing.T) {
+	http.DefaultServeMux = new(http.ServeMux)
+	http.HandleFunc("/tasks", func(rw http.ResponseWriter, r *http.Request) {
+		rw.WriteHeader(http.StatusBadRequest)
+		_, _ = rw.Write([]byte("Content is required\n"))
+	})
+	once.Do(startServer)
+	api := New(validToken, OptionAPIURL("http://"+serverAddr+"/"),
+		OptionInterceptors(RequestInterceptor(func(req *http.Request) error {
+			req.Header.Set("X-Request-ID", "request-1")
+			return nil
+		})))
+
+	_, err := api.AddTask(AddTaskRequest{Content: "Buy milk"})
+
+	statusCodeError := StatusCodeError{}
+	if !errors.As(err, &statusCodeError) {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	expected := StatusCodeError{
+		Code:      http.StatusBadRequest,
+		Status:    "400 Bad Request",
+		Method:    http.MethodPost,
+		Path:      "/tasks",
+		RequestID: "request-1",
+		Body:      "Content is required\n",
+		Message:   "Content is required",
+	}
+	if !reflect.DeepEqual(expected, statusCodeError) {
+		t.Fatal(ErrIncorrectResponse)
+	}
+	if err.Error() != "server error: 400 Bad Request: Content is required [POST /tasks, request id request-1]" {
+		t.Fatalf("Unexpected message: %s", err)
+	}
+}
+
+func TestStatusCodeErrorFromSyncResponse(t *testing.T) {
+	http.DefaultServeMux = new(http.ServeMux)
+	http.HandleFunc("/sync", func(rw http.ResponseWriter, r *http.Request) {
+		rw.WriteHeader(http.StatusForbidden)
+		_, _ = rw.Write([]byte(`{"error":"Access denied","error_code":102,"error_tag":"AUTH_INSUFFICIENT_TOKEN_SCOPE","http_code":403}`))
+	})
+	once.Do(startServer)
+	api := New(validToken, OptionSyncAPIURL("http://"+serverAddr+"/"))
+
+	_, err := api.GetFilters()
+
+	if !errors.Is(err, ErrForbidden) || errors.Is(err, ErrNotFound) {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	statusCodeError := StatusCodeError{}
+	if !errors.As(err, &statusCodeError) {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	if statusCodeError.Message != "Access denied" || statusCodeError.ErrorCode != 102 ||
+		statusCodeError.ErrorTag != "AUTH_INSUFFICIENT_TOKEN_SCOPE" {
+		t.Fatal(ErrIncorrectResponse)
+	}
+}
+
+func TestStatusCodeErrorSentinels(t *testing.T) {
+	http.DefaultServeMux = new(http.ServeMux)
+	http.HandleFunc("/tasks/1", func(rw http.ResponseWriter, r *http.Request) {
+		rw.WriteHeader(http.StatusNotFound)
+	})
+	http.HandleFunc("/projects", func(rw http.ResponseWriter, r *http.Request) {
+		rw.WriteHeader(http.StatusUnauthorized)
+	})
+	once.Do(startServer)
+	api := New(validToken, OptionAPIURL("http://"+serverAddr+"/"))
+
+	if _, err := api.GetActiveTaskById("1"); !errors.Is(err, ErrNotFound) {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	if _, err := api.GetProjects(); !errors.Is(err, ErrUnauthorized) {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+}
+
+func TestRateLimitedErrorRetryAfter(t *testing.T) {
+	http.DefaultServeMux = new(http.ServeMux)
+	http.HandleFunc("/tasks", func(rw http.ResponseWriter, r *http.Request) {
+		rw.WriteHeader(http.StatusTooManyRequests)
+	})
+	http.HandleFunc("/labels", func(rw http.ResponseWriter, r *http.Request) {
+		rw.Header().Set("Retry-After", "7")
+		rw.WriteHeader(http.StatusTooManyRequests)
+	})
+	once.Do(startServer)
+	api := New(validToken, OptionAPIURL("http://"+serverAddr+"/"))
+
+	_, err := api.GetActiveTasks(GetActiveTasksRequest{})
+	rateLimited := &RateLimitedError{}
+	if !errors.As(err, &rateLimited) || rateLimited.RetryAfter != 0 {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+
+	_, err = api.GetLabels()
+	if !errors.As(err, &rateLimited) || rateLimited.RetryAfter != 7*time.Second {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	statusCodeError := StatusCodeError{}
+	if !errors.As(err, &statusCodeError) || statusCodeError.Path != "/labels" {
+		t.Fatal(ErrIncorrectResponse)
+	}
+}
+
+func TestDeleteWithNoContent(t *testing.T) {
+	http.DefaultServeMux = new(http.ServeMux)
+	http.HandleFunc("/tasks/1", func(rw http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodDelete {
+			rw.WriteHeader(http.StatusMethodNotAllowed)
+			return
+		}
+		rw.WriteHeader(http.StatusNoContent)
+	})
+	once.Do(startServer)
+	api := New(validToken, OptionAPIURL("http://"+serverAddr+"/"))
+
+	response, err := api.DeleteTaskById("1")
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	if !reflect.DeepEqual(getTestOkResponse(), *response) {
+		t.Fatal(ErrIncorrectResponse)
+	}
+}
@@ -0,0 +1,108 @@
+package todoist
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// OptionStrictDecoding makes the client reject responses that drifted from
+// this package: unknown priorities, colors or view styles fail with an
+// UnknownValueError, and models with Extra fields with an
+// UnknownFieldsError. Zero values, i.e. missing fields, are accepted. Without
+// it unknown values are passed through and unknown fields kept in Extra.
+func OptionStrictDecoding() func(*Client) {
+	return func(c *Client) { c.strictDecoding = true }
+}
+
+var (
+	enumType       = reflect.TypeOf((*enum)(nil)).Elem()
+	extraType      = reflect.TypeOf(Extra{})
+	rawMessageType = reflect.TypeOf(json.RawMessage{})
+)
+
+// checkStrict walks a decoded response and returns the first unknown enum
+// value or model with unknown fields.
+func checkStrict(value interface{}) error {
+	return checkStrictValue(reflect.ValueOf(value), "")
+}
+
+func checkStrictValue(v reflect.Value, path string) error {
+	if v.Type() == rawMessageType {
+		return nil
+	}
+	if v.Type() == extraType {
+		if v.Len() == 0 {
+			return nil
+		}
+		fields := make([]string, 0, v.Len())
+		for _, key := range v.MapKeys() {
+			fields = append(fields, key.String())
+		}
+		sort.Strings(fields)
+		return &UnknownFieldsError{Field: path, Fields: fields}
+	}
+
+	switch v.Kind() {
+	case reflect.Pointer, reflect.Interface:
+		if v.IsNil() {
+			return nil
+		}
+		return checkStrictValue(v.Elem(), path)
+	case reflect.Struct:
+		for i := 0; i < v.NumField(); i++ {
+			field := v.Type().Field(i)
+			if field.Type == extraType {
+				if err := checkStrictValue(v.Field(i), path); err != nil {
+					return err
+				}
+				continue
+			}
+			if !field.IsExported() || field.Tag.Get("json") == "-" {
+				continue
+			}
+			name := jsonFieldName(field)
+			if field.Anonymous {
+				name = ""
+			}
+			if err := checkStrictValue(v.Field(i), joinFieldPath(path, name)); err != nil {
+				return err
+			}
+		}
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < v.Len(); i++ {
+			if err := checkStrictValue(v.Index(i), fmt.Sprintf("%s[%d]", path, i)); err != nil {
+				return err
+			}
+		}
+	case reflect.Map:
+		iter := v.MapRange()
+		for iter.Next() {
+			if err := checkStrictValue(iter.Value(), joinFieldPath(path, fmt.Sprint(iter.Key()))); err != nil {
+				return err
+			}
+		}
+	default:
+		if v.Type().Implements(enumType) && !v.IsZero() && !v.Interface().(enum).Valid() {
+			return &UnknownValueError{Field: path, Value: v.Interface()}
+		}
+	}
+	return nil
+}
+
+func jsonFieldName(field reflect.StructField) string {
+	name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+	if name == "" {
+		return field.Name
+	}
+	return name
+}
+
+func joinFieldPath(path string, name string) string {
+	if path == "" || name == "" {
+		return path + name
+	}
+	return path + "." + name
+}
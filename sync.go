@@ -0,0 +1,149 @@
+package todoist
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/google/uuid"
+)
+
+// syncCommandsLimit is the maximum number of commands the Sync API accepts in
+// a single request.
+const syncCommandsLimit = 100
+
+type SyncCommand struct {
+	Type   string      `json:"type"`
+	UUID   string      `json:"uuid"`
+	TempId string      `json:"temp_id,omitempty"`
+	Args   interface{} `json:"args"`
+}
+
+type SyncResponse struct {
+	SyncToken     string                     `json:"sync_token"`
+	FullSync      bool                       `json:"full_sync"`
+	SyncStatus    map[string]json.RawMessage `json:"sync_status"`
+	TempIdMapping map[string]string          `json:"temp_id_mapping"`
+}
+
+type SyncError struct {
+	Command   string `json:"-"`
+	ErrorCode int    `json:"error_code"`
+	ErrorTag  string `json:"error_tag"`
+	Err       string `json:"error"`
+	HttpCode  int    `json:"http_code"`
+}
+
+func (e SyncError) Error() string {
+	return fmt.Sprintf("sync command %s failed: %s (%d)", e.Command, e.Err, e.ErrorCode)
+}
+
+type syncRequest struct {
+	SyncToken     string        `json:"sync_token,omitempty"`
+	ResourceTypes []string      `json:"resource_types,omitempty"`
+	Commands      []SyncCommand `json:"commands,omitempty"`
+}
+
+func newSyncCommand(commandType string, args interface{}) SyncCommand {
+	return SyncCommand{
+		Type: commandType,
+		UUID: uuid.New().String(),
+		Args: args,
+	}
+}
+
+func newSyncCommandWithTempId(commandType string, args interface{}) SyncCommand {
+	command := newSyncCommand(commandType, args)
+	command.TempId = uuid.New().String()
+	return command
+}
+
+// CommandError returns the error reported for the command, or nil when the
+// command succeeded.
+func (r *SyncResponse) CommandError(command SyncCommand) error {
+	status, ok := r.SyncStatus[command.UUID]
+	if !ok {
+		return SyncError{Command: command.Type, Err: "missing sync status"}
+	}
+	var text string
+	if json.Unmarshal(status, &text) == nil && text == "ok" {
+		return nil
+	}
+	syncError := SyncError{}
+	if err := json.Unmarshal(status, &syncError); err != nil {
+		return err
+	}
+	syncError.Command = command.Type
+	return syncError
+}
+
+// executeCommands sends the commands to the Sync API, in batches when there
+// are more than a single request accepts. The returned response merges the
+// statuses and temp id mappings of all batches; the error is the first command
+// failure, if any.
+func (api *Client) executeCommands(context context.Context, commands ...SyncCommand) (*SyncResponse, error) {
+	if _, named := OperationFromContext(context); !named {
+		if name, ok := syncCommandOperations[firstCommandType(commands)]; ok {
+			context = withOperationName(context, name)
+		}
+	}
+	merged := &SyncResponse{
+		SyncStatus:    map[string]json.RawMessage{},
+		TempIdMapping: map[string]string{},
+	}
+
+	for start := 0; start < len(commands); start += syncCommandsLimit {
+		end := start + syncCommandsLimit
+		if end > len(commands) {
+			end = len(commands)
+		}
+
+		response := &SyncResponse{}
+		request, err := json.Marshal(syncRequest{Commands: commands[start:end]})
+		if err != nil {
+			return nil, err
+		}
+		err = api.syncPost(context, "sync", api.token, request, response)
+		if err != nil {
+			return nil, err
+		}
+
+		merged.SyncToken = response.SyncToken
+		for k, v := range response.SyncStatus {
+			merged.SyncStatus[k] = v
+		}
+		for k, v := range response.TempIdMapping {
+			merged.TempIdMapping[k] = v
+		}
+	}
+
+	for _, command := range commands {
+		if err := merged.CommandError(command); err != nil {
+			return merged, err
+		}
+	}
+
+	return merged, nil
+}
+
+// readResources performs a full sync of the given resource types and decodes
+// the response into intf.
+func (api *Client) readResources(context context.Context, resourceTypes []string, intf interface{}) error {
+	if len(resourceTypes) > 0 {
+		if name, ok := syncResourceOperations[resourceTypes[0]]; ok {
+			context = withOperationName(context, name)
+		}
+	}
+	request, err := json.Marshal(syncRequest{SyncToken: "*", ResourceTypes: resourceTypes})
+	if err != nil {
+		return err
+	}
+	return api.syncPost(context, "sync", api.token, request, intf)
+}
+
+func firstCommandType(commands []SyncCommand) string {
+	if len(commands) == 0 {
+		return ""
+	}
+	return commands[0].Type
+}
@@ -0,0 +1,104 @@
+package todoist
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"testing"
+)
+
+type testSyncRequest struct {
+	SyncToken     string   `json:"sync_token"`
+	ResourceTypes []string `json:"resource_types"`
+	Commands      []struct {
+		Type   string          `json:"type"`
+		UUID   string          `json:"uuid"`
+		TempId string          `json:"temp_id"`
+		Args   json.RawMessage `json:"args"`
+	} `json:"commands"`
+}
+
+// syncCommandsHandler answers every command with "ok", mapping temp ids to
+// "id-<temp id>", and records the received commands in commands.
+func syncCommandsHandler(commands *[]SyncCommand) func(rw http.ResponseWriter, r *http.Request) {
+	return func(rw http.ResponseWriter, r *http.Request) {
+		request := testSyncRequest{}
+		_ = json.NewDecoder(r.Body).Decode(&request)
+
+		status := map[string]string{}
+		mapping := map[string]string{}
+		for _, command := range request.Commands {
+			status[command.UUID] = "ok"
+			if command.TempId != "" {
+				mapping[command.TempId] = "id-" + command.TempId
+			}
+			*commands = append(*commands, SyncCommand{
+				Type:   command.Type,
+				UUID:   command.UUID,
+				TempId: command.TempId,
+				Args:   command.Args,
+			})
+		}
+		rw.Header().Set("Content-Type", "application/json")
+		response, _ := json.Marshal(map[string]interface{}{
+			"sync_status":     status,
+			"temp_id_mapping": mapping,
+		})
+		_, err := rw.Write(response)
+		if err != nil {
+			return
+		}
+	}
+}
+
+func TestExecuteCommandsInBatches(t *testing.T) {
+	http.DefaultServeMux = new(http.ServeMux)
+	var received []SyncCommand
+	http.HandleFunc("/sync", syncCommandsHandler(&received))
+	once.Do(startServer)
+
+	api := New(validToken, OptionSyncAPIURL("http://"+serverAddr+"/"))
+	var commands []SyncCommand
+	for i := 0; i < syncCommandsLimit+1; i++ {
+		commands = append(commands, newSyncCommandWithTempId("item_add", map[string]string{"content": "task"}))
+	}
+	response, err := api.executeCommands(context.Background(), commands...)
+	if err != nil {
+		t.Errorf("Unexpected error: %s", err)
+		return
+	}
+	if len(received) != len(commands) || len(response.TempIdMapping) != len(commands) {
+		t.Fatal(ErrIncorrectResponse)
+	}
+}
+
+func TestExecuteCommandsError(t *testing.T) {
+	http.DefaultServeMux = new(http.ServeMux)
+	http.HandleFunc("/sync", func(rw http.ResponseWriter, r *http.Request) {
+		request := testSyncRequest{}
+		_ = json.NewDecoder(r.Body).Decode(&request)
+		response, _ := json.Marshal(map[string]interface{}{
+			"sync_status": map[string]interface{}{
+				request.Commands[0].UUID: map[string]interface{}{
+					"error_code": 20,
+					"error":      "Task not found",
+					"error_tag":  "ITEM_NOT_FOUND",
+					"http_code":  404,
+				},
+			},
+		})
+		_, _ = rw.Write(response)
+	})
+	once.Do(startServer)
+
+	api := New(validToken, OptionSyncAPIURL("http://"+serverAddr+"/"))
+	_, err := api.executeCommands(context.Background(), newSyncCommand("item_move", nil))
+	syncError := SyncError{}
+	if !errors.As(err, &syncError) {
+		t.Fatalf("Expected SyncError, got %v", err)
+	}
+	if syncError.Command != "item_move" || syncError.ErrorTag != "ITEM_NOT_FOUND" {
+		t.Fatal(ErrIncorrectResponse)
+	}
+}
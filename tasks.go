@@ -3,8 +3,10 @@ package todoist
 import (
 	"context"
 	"encoding/json"
+	"fmt"
 	"net/url"
 	"strings"
+	"time"
 )
 
 type TasksResponse struct {
@@ -23,40 +25,71 @@ type Due struct {
 	Timezone    string `json:"timezone"`
 }
 
+// Time interprets the due date. Dates without a time and floating date times
+// are read in location, which should be the user's time zone (see
+// User.Location), and time.Local when nil; date times with a fixed time zone
+// keep their own.
+func (d Due) Time(location *time.Location) (time.Time, error) {
+	if location == nil {
+		location = time.Local
+	}
+	if d.Datetime == "" {
+		return time.ParseInLocation("2006-01-02", d.Date, location)
+	}
+	if strings.HasSuffix(d.Datetime, "Z") {
+		return time.Parse(time.RFC3339, d.Datetime)
+	}
+	if d.Timezone != "" {
+		if fixed, err := time.LoadLocation(d.Timezone); err == nil {
+			location = fixed
+		}
+	}
+	return time.ParseInLocation("2006-01-02T15:04:05", d.Datetime, location)
+}
+
 type Task struct {
-	Id           string   `json:"id"`
-	AssignerId   *string  `json:"assigner_id"`
-	AssigneeId   *string  `json:"assignee_id"`
-	ProjectId    string   `json:"project_id"`
-	SectionId    *string  `json:"section_id"`
-	ParentId     *string  `json:"parent_id"`
-	Order        int      `json:"order"`
-	Content      string   `json:"content"`
-	Description  string   `json:"description"`
-	IsCompleted  bool     `json:"is_completed"`
-	Labels       []string `json:"labels"`
-	Priority     int      `json:"priority"`
-	CommentCount int      `json:"comment_count"`
-	CreatorId    string   `json:"creator_id"`
-	CreatedAt    string   `json:"created_at"`
-	Due          *Due     `json:"due"`
-	Url          string   `json:"url"`
+	Id           string        `json:"id"`
+	AssignerId   *string       `json:"assigner_id"`
+	AssigneeId   *string       `json:"assignee_id"`
+	ProjectId    string        `json:"project_id"`
+	SectionId    *string       `json:"section_id"`
+	ParentId     *string       `json:"parent_id"`
+	Order        int           `json:"order"`
+	Content      string        `json:"content"`
+	Description  string        `json:"description"`
+	IsCompleted  bool          `json:"is_completed"`
+	Labels       []string      `json:"labels"`
+	Priority     Priority      `json:"priority"`
+	CommentCount int           `json:"comment_count"`
+	CreatorId    string        `json:"creator_id"`
+	CreatedAt    string        `json:"created_at"`
+	Due          *Due          `json:"due"`
+	Duration     *TaskDuration `json:"duration"`
+	Deadline     *Deadline     `json:"deadline"`
+	Url          string        `json:"url"`
+	Extra        Extra         `json:"-"`
 }
 
+// noDate is the due string removing a due date.
+const noDate = "no date"
+
 type AddTaskRequest struct {
-	Content     string   `json:"content"`
-	Description string   `json:"description"`
-	ProjectId   string   `json:"project_id"`
-	SectionId   *string  `json:"section_id"`
-	ParentId    *string  `json:"parent_id"`
-	Order       *int     `json:"order"`
-	Labels      []string `json:"labels"`
-	Priority    *int     `json:"priority"`
-	DueString   string   `json:"due_string"`
-	DueDate     string   `json:"due_date"`
-	DueDatetime string   `json:"due_datetime"`
-	DueLang     string   `json:"due_lang"`
-	AssigneeId  *string  `json:"assignee_id"`
+	Content      string        `json:"content"`
+	Description  string        `json:"description"`
+	ProjectId    string        `json:"project_id"`
+	SectionId    *string       `json:"section_id"`
+	ParentId     *string       `json:"parent_id"`
+	Order        *int          `json:"order"`
+	Labels       []string      `json:"labels"`
+	Priority     *Priority     `json:"priority"`
+	DueString    string        `json:"due_string"`
+	DueDate      string        `json:"due_date"`
+	DueDatetime  string        `json:"due_datetime"`
+	DueLang      string        `json:"due_lang"`
+	AssigneeId   *string       `json:"assignee_id"`
+	Duration     *TaskDuration `json:"-"`                       // Optional, sent as duration and duration_unit
+	DeadlineDate string        `json:"deadline_date,omitempty"` // Optional, YYYY-MM-DD
+	DeadlineLang string        `json:"deadline_lang,omitempty"` // Optional
 }
 
 type GetActiveTasksRequest struct {
@@ -67,16 +100,196 @@ type GetActiveTasksRequest struct {
 	Lang      string   `json:"lang"`       // Optional
 	Ids       []string `json:"ids"`        // Optional
 }
+
+// UpdateTaskRequest changes the fields that are set and leaves the others
+// alone. Clearing Description sends "", Labels [], Priority 1, any of the due
+// fields due_string "no date", and AssigneeId, Duration and DeadlineDate
+// null. Content cannot be cleared.
 type UpdateTaskRequest struct {
-	Content     string   `json:"content"`      // Optional
-	Description string   `json:"description"`  // Optional
-	Labels      []string `json:"labels"`       // Optional
-	Priority    *int     `json:"priority"`     // Optional
-	DueString   string   `json:"due_string"`   // Optional
-	DueDate     string   `json:"due_date"`     // Optional
-	DueDatetime string   `json:"due_datetime"` // Optional
-	DueLang     string   `json:"due_lang"`     // Optional
-	AssigneeId  string   `json:"assignee_id"`  // Optional
+	Content      Patch[string]       // Optional
+	Description  Patch[string]       // Optional
+	Labels       Patch[[]string]     // Optional
+	Priority     Patch[Priority]     // Optional
+	DueString    Patch[string]       // Optional
+	DueDate      Patch[string]       // Optional
+	DueDatetime  Patch[string]       // Optional
+	DueLang      Patch[string]       // Optional
+	AssigneeId   Patch[string]       // Optional
+	Duration     Patch[TaskDuration] // Optional
+	DeadlineDate Patch[string]       // Optional, YYYY-MM-DD
+	DeadlineLang Patch[string]       // Optional
+}
+
+// MoveTaskRequest selects where a task is moved to. Exactly one of the
+// fields must be set.
+type MoveTaskRequest struct {
+	ProjectId *string `json:"project_id,omitempty"`
+	SectionId *string `json:"section_id,omitempty"`
+	ParentId  *string `json:"parent_id,omitempty"`
+}
+
+type TaskOrder struct {
+	Id         string `json:"id"`
+	ChildOrder int    `json:"child_order"`
+}
+
+// syncItem is a task as returned by the Sync API, which names and shapes some
+// of its fields differently from the REST API.
+type syncItem struct {
+	Id             string        `json:"id"`
+	UserId         string        `json:"user_id"`
+	ProjectId      string        `json:"project_id"`
+	SectionId      *string       `json:"section_id"`
+	ParentId       *string       `json:"parent_id"`
+	Content        string        `json:"content"`
+	Description    string        `json:"description"`
+	Priority       Priority      `json:"priority"`
+	Labels         []string      `json:"labels"`
+	Due            *Due          `json:"due"`
+	Duration       *TaskDuration `json:"duration"`
+	Deadline       *Deadline     `json:"deadline"`
+	ChildOrder     int           `json:"child_order"`
+	DayOrder       int           `json:"day_order"`
+	Collapsed      bool          `json:"collapsed"`
+	Checked        bool          `json:"checked"`
+	IsDeleted      bool          `json:"is_deleted"`
+	SyncId         *string       `json:"sync_id"`
+	AddedByUid     string        `json:"added_by_uid"`
+	AssignedByUid  *string       `json:"assigned_by_uid"`
+	ResponsibleUid *string       `json:"responsible_uid"`
+	AddedAt        string        `json:"added_at"`
+	CompletedAt    *string       `json:"completed_at"`
+	Extra          Extra         `json:"-"`
+}
+
+// task converts the item to a REST task. The Sync API keeps the time of a due
+// date in its date field, which the REST API splits into date and datetime.
+func (i syncItem) task() Task {
+	task := Task{
+		Id:          i.Id,
+		AssignerId:  i.AssignedByUid,
+		AssigneeId:  i.ResponsibleUid,
+		ProjectId:   i.ProjectId,
+		SectionId:   i.SectionId,
+		ParentId:    i.ParentId,
+		Order:       i.ChildOrder,
+		Content:     i.Content,
+		Description: i.Description,
+		IsCompleted: i.Checked,
+		Labels:      i.Labels,
+		Priority:    i.Priority,
+		CreatorId:   i.AddedByUid,
+		CreatedAt:   i.AddedAt,
+		Duration:    i.Duration,
+		Deadline:    i.Deadline,
+		Url:         "https://todoist.com/showTask?id=" + i.Id,
+		Extra:       i.Extra,
+	}
+	if i.Due != nil {
+		due := *i.Due
+		if date, _, timed := strings.Cut(due.Date, "T"); timed {
+			due.Datetime, due.Date = due.Date, date
+		}
+		task.Due = &due
+	}
+	return task
+}
+
+type moveTaskArgs struct {
+	Id string `json:"id"`
+	MoveTaskRequest
+}
+
+// Validate checks the request before AddTask sends it.
+func (r AddTaskRequest) Validate() error {
+	v := newValidator("AddTaskRequest")
+	v.required("content", r.Content)
+	v.maxLength("content", r.Content, maxTaskContentLength)
+	v.maxLength("description", r.Description, maxTaskDescriptionLength)
+	v.priority("priority", r.Priority)
+	v.due(r.DueString, r.DueDate, r.DueDatetime)
+	if r.Duration != nil {
+		v.duration(r.Duration.Amount, r.Duration.Unit)
+	}
+	v.date("deadline_date", r.DeadlineDate)
+	return v.err()
+}
+
+// MarshalJSON writes Duration as the duration and duration_unit fields.
+func (r AddTaskRequest) MarshalJSON() ([]byte, error) {
+	type addTaskRequest AddTaskRequest
+	request := struct {
+		addTaskRequest
+		Duration     int          `json:"duration,omitempty"`
+		DurationUnit DurationUnit `json:"duration_unit,omitempty"`
+	}{addTaskRequest: addTaskRequest(r)}
+	if r.Duration != nil {
+		request.Duration, request.DurationUnit = r.Duration.Amount, r.Duration.Unit
+	}
+	return json.Marshal(request)
+}
+
+// Validate checks the request before UpdateTask sends it.
+func (r UpdateTaskRequest) Validate() error {
+	v := newValidator("UpdateTaskRequest")
+	if r.Content.IsCleared() {
+		v.add("content", "cannot be cleared")
+	}
+	content, _ := r.Content.Value()
+	v.maxLength("content", content, maxTaskContentLength)
+	description, _ := r.Description.Value()
+	v.maxLength("description", description, maxTaskDescriptionLength)
+	if priority, ok := r.Priority.Value(); ok {
+		v.priority("priority", &priority)
+	}
+	dueString, _ := r.DueString.Value()
+	dueDate, _ := r.DueDate.Value()
+	dueDatetime, _ := r.DueDatetime.Value()
+	dueCleared := r.DueString.IsCleared() || r.DueDate.IsCleared() || r.DueDatetime.IsCleared()
+	if dueCleared && (dueString != "" || dueDate != "" || dueDatetime != "") {
+		v.add("due_string", "cannot clear and set the due date at once")
+	}
+	v.due(dueString, dueDate, dueDatetime)
+	if duration, ok := r.Duration.Value(); ok {
+		if duration.Amount == 0 && duration.Unit == "" {
+			v.add("duration", "is empty, clear it instead")
+		}
+		v.duration(duration.Amount, duration.Unit)
+	}
+	deadline, _ := r.DeadlineDate.Value()
+	v.date("deadline_date", deadline)
+	return v.err()
+}
+
+func (r UpdateTaskRequest) MarshalJSON() ([]byte, error) {
+	fields := patchFields{}
+	addPatch(fields, "content", r.Content, nil)
+	addPatch(fields, "description", r.Description, "")
+	if labels, ok := r.Labels.Value(); ok && labels == nil {
+		r.Labels = Set([]string{})
+	}
+	addPatch(fields, "labels", r.Labels, []string{})
+	addPatch(fields, "priority", r.Priority, PriorityNormal)
+	addPatch(fields, "due_string", r.DueString, noDate)
+	addPatch(fields, "due_date", r.DueDate, nil)
+	addPatch(fields, "due_datetime", r.DueDatetime, nil)
+	if r.DueDate.IsCleared() || r.DueDatetime.IsCleared() {
+		delete(fields, "due_date")
+		delete(fields, "due_datetime")
+		fields["due_string"] = noDate
+	}
+	addPatch(fields, "due_lang", r.DueLang, nil)
+	addPatch(fields, "assignee_id", r.AssigneeId, nil)
+	if duration, ok := r.Duration.Value(); ok {
+		fields["duration"] = duration.Amount
+		fields["duration_unit"] = duration.Unit
+	} else if r.Duration.IsCleared() {
+		fields["duration"] = nil
+		fields["duration_unit"] = nil
+	}
+	addPatch(fields, "deadline_date", r.DeadlineDate, nil)
+	addPatch(fields, "deadline_lang", r.DeadlineLang, nil)
+	return json.Marshal(fields)
 }
 
 func (api *Client) GetActiveTasks(getActiveTasksRequest GetActiveTasksRequest) (*[]Task, error) {
@@ -100,28 +313,43 @@ func (api *Client) ReopenTask(id string) (*TodoistResponse, error) {
 func (api *Client) DeleteTaskById(id string) (*TodoistResponse, error) {
 	return api.DeleteTaskByIdContext(id, context.Background())
 }
+func (api *Client) MoveTask(id string, request MoveTaskRequest) (*TodoistResponse, error) {
+	return api.MoveTaskContext(id, request, context.Background())
+}
+func (api *Client) MoveTasks(ids []string, request MoveTaskRequest) (*TodoistResponse, error) {
+	return api.MoveTasksContext(ids, request, context.Background())
+}
+func (api *Client) ReorderTasks(orders []TaskOrder) (*TodoistResponse, error) {
+	return api.ReorderTasksContext(orders, context.Background())
+}
 
 func (api *Client) GetActiveTasksContext(request GetActiveTasksRequest, context context.Context) (*[]Task, error) {
 	response := &TasksResponse{}
-	values := url.Values{
-		"project_id": {request.ProjectId},
-		"section_id": {request.SectionId},
-		"label":      {request.Label},
-		"filter":     {request.Filter},
-		"lang":       {request.Lang},
-		"ids":        {strings.Join(request.Ids, ",")},
-	}
 
 	err := api.get(context,
 		"tasks",
 		api.token,
-		values,
+		request.values(),
 		&response.Tasks)
 
 	return &response.Tasks, err
 }
 
+func (request GetActiveTasksRequest) values() url.Values {
+	return url.Values{
+		"project_id": {request.ProjectId},
+		"section_id": {request.SectionId},
+		"label":      {request.Label},
+		"filter":     {request.Filter},
+		"lang":       {request.Lang},
+		"ids":        {strings.Join(request.Ids, ",")},
+	}
+}
+
 func (api *Client) AddTaskContext(addTaskRequest AddTaskRequest, context context.Context) (*Task, error) {
+	if err := addTaskRequest.Validate(); err != nil {
+		return nil, err
+	}
 	response := &TaskResponse{}
 
 	request, err := json.Marshal(addTaskRequest)
@@ -146,6 +374,9 @@ func (api *Client) GetActiveTaskByIdContext(id string, context context.Context)
 	return &response.Task, err
 }
 func (api *Client) UpdateTaskContext(id string, updateTaskRequest UpdateTaskRequest, context context.Context) (*Task, error) {
+	if err := updateTaskRequest.Validate(); err != nil {
+		return nil, err
+	}
 	response := &TaskResponse{}
 	request, _ := json.Marshal(updateTaskRequest)
 	err := api.post(context, "tasks/"+id, api.token, request, &response.Task)
@@ -158,7 +389,7 @@ func (api *Client) UpdateTaskContext(id string, updateTaskRequest UpdateTaskRequ
 }
 func (api *Client) CloseTaskContext(id string, context context.Context) (*TodoistResponse, error) {
 	response := &TodoistResponse{}
-	err := performPostWithoutResponse(context, api.httpclient, api.endpoint+"tasks/"+id+"/close", api.token, &response, api)
+	err := performPostWithoutResponse(context, api.httpclient, api.endpoint+"tasks/"+id+"/close", api.token, response, api)
 
 	if err != nil {
 		return nil, err
@@ -171,7 +402,7 @@ func (api *Client) CloseTaskContext(id string, context context.Context) (*Todois
 }
 func (api *Client) ReopenTaskContext(id string, context context.Context) (*TodoistResponse, error) {
 	response := &TodoistResponse{}
-	err := performPostWithoutResponse(context, api.httpclient, api.endpoint+"tasks/"+id+"/reopen", api.token, &response, api)
+	err := performPostWithoutResponse(context, api.httpclient, api.endpoint+"tasks/"+id+"/reopen", api.token, response, api)
 
 	if err != nil {
 		return nil, err
@@ -194,3 +425,39 @@ func (api *Client) DeleteTaskByIdContext(id string, context context.Context) (*T
 
 	return response, err
 }
+
+func (api *Client) MoveTaskContext(id string, request MoveTaskRequest, context context.Context) (*TodoistResponse, error) {
+	return api.MoveTasksContext([]string{id}, request, context)
+}
+func (api *Client) MoveTasksContext(ids []string, request MoveTaskRequest, context context.Context) (*TodoistResponse, error) {
+	targets := 0
+	for _, target := range []*string{request.ProjectId, request.SectionId, request.ParentId} {
+		if target != nil {
+			targets++
+		}
+	}
+	if targets != 1 {
+		return nil, fmt.Errorf("exactly one of project_id, section_id or parent_id is required to move tasks")
+	}
+
+	commands := make([]SyncCommand, 0, len(ids))
+	for _, id := range ids {
+		commands = append(commands, newSyncCommand("item_move", moveTaskArgs{Id: id, MoveTaskRequest: request}))
+	}
+	_, err := api.executeCommands(context, commands...)
+	if err != nil {
+		return nil, err
+	}
+
+	return &TodoistResponse{Ok: true}, nil
+}
+func (api *Client) ReorderTasksContext(orders []TaskOrder, context context.Context) (*TodoistResponse, error) {
+	_, err := api.executeCommands(context, newSyncCommand("item_reorder", map[string][]TaskOrder{
+		"items": orders,
+	}))
+	if err != nil {
+		return nil, err
+	}
+
+	return &TodoistResponse{Ok: true}, nil
+}
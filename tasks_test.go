@@ -60,7 +60,7 @@ func TestAddTask(t *testing.T) {
 	sectionID := "4"
 	parentID := "5"
 	assigneeID := "6"
-	priority := 2
+	priority := PriorityMedium
 	order := 1
 	request1 := AddTaskRequest{
 		Content:     "1",
@@ -73,8 +73,6 @@ func TestAddTask(t *testing.T) {
 		Priority:    &priority,
 		AssigneeId:  &assigneeID,
 		DueString:   "7",
-		DueDate:     "8",
-		DueDatetime: "9",
 		DueLang:     "0",
 	}
 	task, err := api.AddTask(request1)
@@ -98,8 +96,8 @@ func TestUpdateTask(t *testing.T) {
 
 	api := New(validToken, OptionAPIURL("http://"+serverAddr+"/"))
 	request := UpdateTaskRequest{
-		Content:     "",
-		Description: "",
+		Content:     Set("Buy milk"),
+		Description: Clear[string](),
 	}
 	task, err := api.UpdateTask("1", request)
 	if err != nil {
@@ -264,3 +262,54 @@ func getOkResponse(rw http.ResponseWriter, _ *http.Request) {
 		return
 	}
 }
+
+func TestMoveTask(t *testing.T) {
+	http.DefaultServeMux = new(http.ServeMux)
+	var commands []SyncCommand
+	http.HandleFunc("/sync", syncCommandsHandler(&commands))
+	once.Do(startServer)
+	expectedResponse := getTestOkResponse()
+
+	api := New(validToken, OptionSyncAPIURL("http://"+serverAddr+"/"))
+	sectionID := "2"
+	response, err := api.MoveTask("1", MoveTaskRequest{SectionId: &sectionID})
+	if err != nil {
+		t.Errorf("Unexpected error: %s", err)
+		return
+	}
+	if !reflect.DeepEqual(expectedResponse, *response) {
+		t.Fatal(ErrIncorrectResponse)
+	}
+	if len(commands) != 1 || commands[0].Type != "item_move" ||
+		string(commands[0].Args.(json.RawMessage)) != `{"id":"1","section_id":"2"}` {
+		t.Fatalf("Unexpected commands: %+v", commands)
+	}
+}
+
+func TestMoveTasksRequiresSingleTarget(t *testing.T) {
+	api := New(validToken)
+	projectID := "1"
+	sectionID := "2"
+	_, err := api.MoveTasks([]string{"1"}, MoveTaskRequest{ProjectId: &projectID, SectionId: &sectionID})
+	if err == nil {
+		t.Errorf("Succeeded, but should have failed")
+	}
+}
+
+func TestReorderTasks(t *testing.T) {
+	http.DefaultServeMux = new(http.ServeMux)
+	var commands []SyncCommand
+	http.HandleFunc("/sync", syncCommandsHandler(&commands))
+	once.Do(startServer)
+
+	api := New(validToken, OptionSyncAPIURL("http://"+serverAddr+"/"))
+	_, err := api.ReorderTasks([]TaskOrder{{Id: "1", ChildOrder: 2}, {Id: "2", ChildOrder: 1}})
+	if err != nil {
+		t.Errorf("Unexpected error: %s", err)
+		return
+	}
+	if len(commands) != 1 || commands[0].Type != "item_reorder" ||
+		string(commands[0].Args.(json.RawMessage)) != `{"items":[{"id":"1","child_order":2},{"id":"2","child_order":1}]}` {
+		t.Fatalf("Unexpected commands: %+v", commands)
+	}
+}
@@ -5,22 +5,32 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"log/slog"
 	"net/http"
 	"net/url"
+	"sync"
 
 	"github.com/google/uuid"
 )
 
 const (
-	APIURL = "https://api.todoist.com/rest/v2/"
+	APIURL     = "https://api.todoist.com/rest/v2/"
+	SyncAPIURL = "https://api.todoist.com/sync/v9/"
 )
 
 type Client struct {
-	token      string
-	endpoint   string
-	debug      bool
-	log        ilogger
-	httpclient httpClient
+	token          string
+	endpoint       string
+	syncEndpoint   string
+	logger         *slog.Logger
+	redactFields   map[string]bool
+	httpclient     httpClient
+	limiter        *rateLimiter
+	interceptors   []Interceptor
+	strictDecoding bool
+	coalescer      *coalescer
+	bulkOnce       sync.Once
+	bulkLimiter    *rateLimiter
 }
 
 type TodoistResponse struct {
@@ -28,27 +38,6 @@ type TodoistResponse struct {
 	Error string `json:"error"`
 }
 
-func (api *Client) Debugf(format string, v ...interface{}) {
-	if api.debug {
-		err := api.log.Output(2, fmt.Sprintf(format, v...))
-		if err != nil {
-			return
-		}
-	}
-}
-func (api *Client) Debugln(v ...interface{}) {
-	if api.debug {
-		err := api.log.Output(2, fmt.Sprintln(v...))
-		if err != nil {
-			return
-		}
-	}
-}
-
-func (api *Client) Debug() bool {
-	return api.debug
-}
-
 type httpClient interface {
 	Do(*http.Request) (*http.Response, error)
 }
@@ -56,11 +45,20 @@ type httpClient interface {
 func (api *Client) post(ctx context.Context, path string, token string, json []byte, intf interface{}) error {
 	return performPost(ctx, api.httpclient, api.endpoint+path, token, json, intf, api)
 }
+func (api *Client) syncPost(ctx context.Context, path string, token string, json []byte, intf interface{}) error {
+	return performPost(ctx, api.httpclient, api.syncEndpoint+path, token, json, intf, api)
+}
 func (api *Client) get(ctx context.Context, path string, token string, values url.Values, intf interface{}) error {
+	if api.coalescer != nil && token == api.token {
+		return api.coalescedGet(ctx, api.endpoint+path, values, intf)
+	}
 	return performGet(ctx, api.httpclient, api.endpoint+path, token, values, intf, api)
 }
+func (api *Client) syncGet(ctx context.Context, path string, token string, values url.Values, intf interface{}) error {
+	return performGet(ctx, api.httpclient, api.syncEndpoint+path, token, values, intf, api)
+}
 
-func performPost(ctx context.Context, client httpClient, endpoint, token string, json []byte, intf interface{}, d Debug) error {
+func performPost(ctx context.Context, client httpClient, endpoint, token string, json []byte, intf interface{}, api *Client) error {
 	reqBody := bytes.NewBuffer(json)
 	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, reqBody)
 	if err != nil {
@@ -70,19 +68,22 @@ func performPost(ctx context.Context, client httpClient, endpoint, token string,
 	req.Header.Set("X-Request-ID", uuid.New().String())
 	req.Header.Set("Content-Type", "application/json")
 
-	return perform(client, req, newJSONParser(intf), d)
+	return perform(client, req, newJSONParser(intf, api.strictDecoding), api)
 }
-func performPostWithoutResponse(ctx context.Context, client httpClient, endpoint, token string, intf interface{}, d Debug) error {
+func performPostWithoutResponse(ctx context.Context, client httpClient, endpoint, token string, intf interface{}, api *Client) error {
 	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, nil)
 	if err != nil {
 		return err
 	}
 	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", token))
 
-	return perform(client, req, newJSONParser(intf), d)
+	return perform(client, req, newJSONParser(intf, api.strictDecoding), api)
 }
 
-func performGet(ctx context.Context, client httpClient, endpoint, token string, values url.Values, intf interface{}, d Debug) error {
+func performGet(ctx context.Context, client httpClient, endpoint, token string, values url.Values, intf interface{}, api *Client) error {
+	return performGetWithParser(ctx, client, endpoint, token, values, newJSONParser(intf, api.strictDecoding), api)
+}
+func performGetWithParser(ctx context.Context, client httpClient, endpoint, token string, values url.Values, parser responseParser, api *Client) error {
 	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint, nil)
 	if err != nil {
 		return err
@@ -91,22 +92,33 @@ func performGet(ctx context.Context, client httpClient, endpoint, token string,
 
 	req.URL.RawQuery = values.Encode()
 
-	return perform(client, req, newJSONParser(intf), d)
+	return perform(client, req, parser, api)
 }
-func performDelete(ctx context.Context, client httpClient, endpoint, token string, intf interface{}, d Debug) error {
+func performDelete(ctx context.Context, client httpClient, endpoint, token string, intf interface{}, api *Client) error {
 	req, err := http.NewRequestWithContext(ctx, http.MethodDelete, endpoint, nil)
 	if err != nil {
 		return err
 	}
 	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", token))
-	return perform(client, req, newJSONParser(intf), d)
+	return perform(client, req, newJSONParser(intf, api.strictDecoding), api)
 }
 
-func newJSONParser(dst interface{}) responseParser {
+func newJSONParser(dst interface{}, strict bool) responseParser {
 	return func(resp *http.Response) error {
 		if dst == nil {
 			return nil
 		}
-		return json.NewDecoder(resp.Body).Decode(dst)
+		if resp.StatusCode == http.StatusNoContent {
+			// Deletes, closes and reopens answer without a body.
+			if response, ok := dst.(*TodoistResponse); ok {
+				response.Ok = true
+			}
+			return nil
+		}
+		err := json.NewDecoder(resp.Body).Decode(dst)
+		if err == nil && strict {
+			err = checkStrict(dst)
+		}
+		return err
 	}
 }
@@ -0,0 +1,49 @@
+package todoistics
+
+import (
+	"bytes"
+	"net/http"
+
+	"github.com/volyanyk/todoist"
+)
+
+// Feed serves the active tasks matching a request, e.g. the ones of a project
+// or a filter, as a calendar. The tasks are fetched on every request. A feed
+// has no authentication of its own: anyone reaching it reads the tasks, so
+// serve it behind a secret path or wrap it with a handler checking access.
+type Feed struct {
+	api     *todoist.Client
+	request todoist.GetActiveTasksRequest
+	options Options
+}
+
+func NewFeed(api *todoist.Client, request todoist.GetActiveTasksRequest, options Options) *Feed {
+	return &Feed{api: api, request: request, options: options}
+}
+
+func (f *Feed) ServeHTTP(rw http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet && r.Method != http.MethodHead {
+		rw.Header().Set("Allow", "GET, HEAD")
+		http.Error(rw, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+		return
+	}
+
+	it := f.api.IterateActiveTasksContext(f.request, r.Context())
+	defer it.Close()
+	var tasks []todoist.Task
+	for it.Next() {
+		tasks = append(tasks, it.Value())
+	}
+	if err := it.Err(); err != nil {
+		http.Error(rw, "fetching tasks failed", http.StatusBadGateway)
+		return
+	}
+
+	var body bytes.Buffer
+	if err := Encode(&body, tasks, f.options); err != nil {
+		http.Error(rw, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	rw.Header().Set("Content-Type", "text/calendar; charset=utf-8")
+	_, _ = body.WriteTo(rw)
+}
@@ -0,0 +1,326 @@
+package todoistics
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/volyanyk/todoist"
+)
+
+// ImportOptions configures Import.
+type ImportOptions struct {
+	ProjectId string // Optional, defaults to the inbox
+	SectionId string // Optional
+	// Location is the zone of floating times and of the times of recurring
+	// due strings, which Todoist reads in the zone of the user. Optional,
+	// defaults to time.Local, the zone of the importing machine rather than
+	// the user's.
+	Location *time.Location
+}
+
+// Import adds the VTODO and VEVENT entries of a calendar as tasks, see
+// Decode. It stops at the first task that cannot be added and returns the
+// tasks added until then.
+func Import(ctx context.Context, api *todoist.Client, r io.Reader, options ImportOptions) ([]todoist.Task, error) {
+	requests, err := Decode(r, options.Location)
+	if err != nil {
+		return nil, err
+	}
+	var tasks []todoist.Task
+	for _, request := range requests {
+		request.ProjectId = options.ProjectId
+		if options.SectionId != "" {
+			sectionId := options.SectionId
+			request.SectionId = &sectionId
+		}
+		task, err := api.AddTaskContext(request, ctx)
+		if err != nil {
+			return tasks, fmt.Errorf("adding %q: %w", request.Content, err)
+		}
+		tasks = append(tasks, *task)
+	}
+	return tasks, nil
+}
+
+// Decode reads the VTODO and VEVENT entries of a calendar as task requests,
+// the reverse of Encode. Completed and cancelled entries are skipped. Floating
+// times are read in location, time.Local when nil.
+//
+// The start of an event, or the due date of a todo, becomes the due date. An
+// RRULE that DueString converts makes it a recurring due string starting at
+// that date instead, at the time of day in location; other rules are dropped.
+// The end or DURATION becomes the task duration. A URL is appended to the
+// description.
+func Decode(r io.Reader, location *time.Location) ([]todoist.AddTaskRequest, error) {
+	if location == nil {
+		location = time.Local
+	}
+	entries, err := parse(r)
+	if err != nil {
+		return nil, err
+	}
+	var requests []todoist.AddTaskRequest
+	for i, e := range entries {
+		status := strings.ToUpper(e.value("STATUS"))
+		if status == "COMPLETED" || status == "CANCELLED" {
+			continue
+		}
+		request, err := e.request(location)
+		if err != nil {
+			return nil, fmt.Errorf("%s %d: %w", e.component, i+1, err)
+		}
+		requests = append(requests, request)
+	}
+	return requests, nil
+}
+
+func (e *entry) request(location *time.Location) (todoist.AddTaskRequest, error) {
+	request := todoist.AddTaskRequest{
+		Content:     unescapeText(e.value("SUMMARY")),
+		Description: unescapeText(e.value("DESCRIPTION")),
+	}
+	if request.Content == "" {
+		return request, fmt.Errorf("missing SUMMARY")
+	}
+	if url := e.value("URL"); url != "" && !strings.Contains(request.Description, url) {
+		request.Description = strings.TrimSpace(request.Description + "\n\n" + url)
+	}
+	for _, p := range e.properties["CATEGORIES"] {
+		for _, label := range splitText(p.value) {
+			if label = strings.TrimSpace(unescapeText(label)); label != "" {
+				request.Labels = append(request.Labels, label)
+			}
+		}
+	}
+	if value := e.value("PRIORITY"); value != "" {
+		level, err := strconv.Atoi(value)
+		if err != nil {
+			return request, fmt.Errorf("invalid PRIORITY %q", value)
+		}
+		priority := taskPriority(level)
+		request.Priority = &priority
+	}
+
+	startName, endName := "DTSTART", "DTEND"
+	if e.component == string(ComponentTodo) {
+		endName = "DUE"
+		if _, ok := e.property("DTSTART"); !ok {
+			startName, endName = "DUE", ""
+		}
+	}
+	startProperty, ok := e.property(startName)
+	if !ok {
+		return request, nil
+	}
+	start, allDay, err := parseTime(startProperty, location)
+	if err != nil {
+		return request, err
+	}
+
+	var length time.Duration
+	if endProperty, ok := e.property(endName); ok && endName != "" {
+		end, _, err := parseTime(endProperty, location)
+		if err != nil {
+			return request, err
+		}
+		length = end.Sub(start)
+	} else if value := e.value("DURATION"); value != "" {
+		if length, err = parseDuration(value); err != nil {
+			return request, err
+		}
+	}
+	if allDay && length > 24*time.Hour {
+		request.Duration = &todoist.TaskDuration{Amount: int(length / (24 * time.Hour)), Unit: todoist.DurationUnitDay}
+	} else if !allDay && length > 0 {
+		duration := todoist.NewTaskDuration(length)
+		request.Duration = &duration
+	}
+
+	if rule := e.value("RRULE"); rule != "" {
+		if dueString, ok := DueString(rule); ok {
+			local := start
+			if !allDay {
+				local = start.In(location)
+				every, until, hasUntil := strings.Cut(dueString, " until ")
+				dueString = every + local.Format(" at 15:04")
+				if hasUntil {
+					dueString += " until " + until
+				}
+			}
+			request.DueString = dueString + local.Format(" starting 2006-01-02")
+			request.DueLang = "en"
+			return request, nil
+		}
+	}
+	if allDay {
+		request.DueDate = start.Format("2006-01-02")
+	} else {
+		request.DueDatetime = start.UTC().Format(time.RFC3339)
+	}
+	return request, nil
+}
+
+func parseTime(p property, location *time.Location) (time.Time, bool, error) {
+	value := p.value
+	if p.params["VALUE"] == "DATE" || len(value) == len(dateFormat) {
+		t, err := time.Parse(dateFormat, value)
+		return t, true, err
+	}
+	if strings.HasSuffix(value, "Z") {
+		t, err := time.Parse(utcFormat, value)
+		return t, false, err
+	}
+	if tzid := strings.Trim(p.params["TZID"], `"`); tzid != "" {
+		zone, err := time.LoadLocation(tzid)
+		if err != nil {
+			return time.Time{}, false, fmt.Errorf("unknown TZID %q", tzid)
+		}
+		location = zone
+	}
+	t, err := time.ParseInLocation(dateTimeFormat, value, location)
+	return t, false, err
+}
+
+var durationPattern = regexp.MustCompile(`^\+?P(?:(\d+)W)?(?:(\d+)D)?(?:T(?:(\d+)H)?(?:(\d+)M)?(?:(\d+)S)?)?$`)
+
+// parseDuration parses the positive durations of RFC 5545, e.g. PT1H30M.
+func parseDuration(value string) (time.Duration, error) {
+	match := durationPattern.FindStringSubmatch(value)
+	if match == nil {
+		return 0, fmt.Errorf("invalid DURATION %q", value)
+	}
+	var d time.Duration
+	for i, unit := range []time.Duration{7 * 24 * time.Hour, 24 * time.Hour, time.Hour, time.Minute, time.Second} {
+		if match[i+1] != "" {
+			n, _ := strconv.Atoi(match[i+1])
+			d += time.Duration(n) * unit
+		}
+	}
+	return d, nil
+}
+
+type entry struct {
+	component  string
+	properties map[string][]property
+}
+
+type property struct {
+	params map[string]string
+	value  string
+}
+
+func (e *entry) property(name string) (property, bool) {
+	properties := e.properties[name]
+	if len(properties) == 0 {
+		return property{}, false
+	}
+	return properties[0], true
+}
+
+func (e *entry) value(name string) string {
+	p, _ := e.property(name)
+	return p.value
+}
+
+// parse reads the VTODO and VEVENT entries of a calendar, skipping their
+// subcomponents such as VALARM.
+func parse(r io.Reader) ([]*entry, error) {
+	lines, err := unfold(r)
+	if err != nil {
+		return nil, err
+	}
+	var entries []*entry
+	var current *entry
+	depth := 0
+	for n, line := range lines {
+		if line == "" {
+			continue
+		}
+		name, params, value, err := parseLine(line)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", n+1, err)
+		}
+		switch {
+		case name == "BEGIN" && current == nil:
+			if value == string(ComponentTodo) || value == string(ComponentEvent) {
+				current = &entry{component: value, properties: map[string][]property{}}
+			}
+		case name == "BEGIN":
+			depth++
+		case name == "END" && current != nil && depth > 0:
+			depth--
+		case name == "END" && current != nil:
+			entries = append(entries, current)
+			current = nil
+		case current != nil && depth == 0:
+			current.properties[name] = append(current.properties[name], property{params: params, value: value})
+		}
+	}
+	return entries, nil
+}
+
+// unfold joins the continuation lines of a calendar.
+func unfold(r io.Reader) ([]string, error) {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	var lines []string
+	for scanner.Scan() {
+		line := strings.TrimSuffix(scanner.Text(), "\r")
+		if len(lines) > 0 && (strings.HasPrefix(line, " ") || strings.HasPrefix(line, "\t")) {
+			lines[len(lines)-1] += line[1:]
+			continue
+		}
+		lines = append(lines, line)
+	}
+	return lines, scanner.Err()
+}
+
+// parseLine splits a content line into its upper-cased name, its parameters
+// and its value.
+func parseLine(line string) (string, map[string]string, string, error) {
+	quoted := false
+	colon := -1
+	for i := 0; i < len(line) && colon < 0; i++ {
+		switch line[i] {
+		case '"':
+			quoted = !quoted
+		case ':':
+			if !quoted {
+				colon = i
+			}
+		}
+	}
+	if colon < 0 {
+		return "", nil, "", fmt.Errorf("missing colon in %q", line)
+	}
+
+	parts := strings.Split(line[:colon], ";")
+	params := map[string]string{}
+	for _, param := range parts[1:] {
+		key, value, _ := strings.Cut(param, "=")
+		params[strings.ToUpper(key)] = value
+	}
+	return strings.ToUpper(parts[0]), params, line[colon+1:], nil
+}
+
+// splitText splits a list of text values on the commas that are not escaped.
+func splitText(value string) []string {
+	var values []string
+	start := 0
+	for i := 0; i < len(value); i++ {
+		switch value[i] {
+		case '\\':
+			i++
+		case ',':
+			values = append(values, value[start:i])
+			start = i + 1
+		}
+	}
+	return append(values, value[start:])
+}
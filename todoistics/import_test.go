@@ -0,0 +1,157 @@
+package todoistics
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/volyanyk/todoist"
+)
+
+const testCalendar = "BEGIN:VCALENDAR\r\n" +
+	"VERSION:2.0\r\n" +
+	"BEGIN:VTIMEZONE\r\n" +
+	"TZID:Europe/Berlin\r\n" +
+	"END:VTIMEZONE\r\n" +
+	"BEGIN:VEVENT\r\n" +
+	"UID:a@example.com\r\n" +
+	"SUMMARY:Release\\, finally\r\n" +
+	"DESCRIPTION:Tag and\\n publish\r\n" +
+	"URL:https://example.com/release\r\n" +
+	"DTSTART;TZID=Europe/Berlin:20240112T100000\r\n" +
+	"DTEND;TZID=Europe/Berlin:20240112T113000\r\n" +
+	"CATEGORIES:work,release\\,ops\r\n" +
+	"PRIORITY:1\r\n" +
+	"BEGIN:VALARM\r\n" +
+	"ACTION:DISPLAY\r\n" +
+	"DESCRIPTION:Reminder\r\n" +
+	"END:VALARM\r\n" +
+	"END:VEVENT\r\n" +
+	"BEGIN:VTODO\r\n" +
+	"SUMMARY:Standup notes\r\n" +
+	"DTSTART:20240108T080000Z\r\n" +
+	"RRULE:FREQ=WEEKLY;BYDAY=MO,WE;UNTIL=20240301T000000Z\r\n" +
+	"DURATION:PT15M\r\n" +
+	"END:VTODO\r\n" +
+	"BEGIN:VTODO\r\n" +
+	"SUMMARY:Taxes\r\n" +
+	"DUE;VALUE=DATE:20240430\r\n" +
+	"PRIORITY:9\r\n" +
+	"END:VTODO\r\n" +
+	"BEGIN:VEVENT\r\n" +
+	"SUMMARY:Offsite\r\n" +
+	"DTSTART;VALUE=DATE:20240220\r\n" +
+	"DTEND;VALUE=DATE:20240223\r\n" +
+	"RRULE:FREQ=YEARLY;COUNT=3\r\n" +
+	"END:VEVENT\r\n" +
+	"BEGIN:VTODO\r\n" +
+	"SUMMARY:Done already\r\n" +
+	"STATUS:COMPLETED\r\n" +
+	"END:VTODO\r\n" +
+	"END:VCALENDAR\r\n"
+
+func priority(p todoist.Priority) *todoist.Priority {
+	return &p
+}
+
+func TestDecode(t *testing.T) {
+	berlin, err := time.LoadLocation("Europe/Berlin")
+	if err != nil {
+		t.Skip("time zone database unavailable")
+	}
+	requests, err := Decode(strings.NewReader(testCalendar), berlin)
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+
+	expected := []todoist.AddTaskRequest{
+		{
+			Content:     "Release, finally",
+			Description: "Tag and\n publish\n\nhttps://example.com/release",
+			Labels:      []string{"work", "release,ops"},
+			Priority:    priority(todoist.PriorityUrgent),
+			DueDatetime: "2024-01-12T09:00:00Z",
+			Duration:    &todoist.TaskDuration{Amount: 90, Unit: todoist.DurationUnitMinute},
+		},
+		{
+			Content:   "Standup notes",
+			DueString: "every monday, wednesday at 09:00 until 2024-03-01 starting 2024-01-08",
+			DueLang:   "en",
+			Duration:  &todoist.TaskDuration{Amount: 15, Unit: todoist.DurationUnitMinute},
+		},
+		{
+			Content:  "Taxes",
+			Priority: priority(todoist.PriorityMedium),
+			DueDate:  "2024-04-30",
+		},
+		{
+			Content:  "Offsite",
+			DueDate:  "2024-02-20",
+			Duration: &todoist.TaskDuration{Amount: 3, Unit: todoist.DurationUnitDay},
+		},
+	}
+	if !reflect.DeepEqual(requests, expected) {
+		t.Errorf("Unexpected requests:\n%+v\nexpected:\n%+v", requests, expected)
+	}
+}
+
+func TestDecodeRoundTrip(t *testing.T) {
+	var b strings.Builder
+	if err := Encode(&b, getTestTasks(), Options{}); err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	requests, err := Decode(strings.NewReader(b.String()), time.UTC)
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	if len(requests) != 3 {
+		t.Fatalf("Expected 3 requests, got %d", len(requests))
+	}
+	first := requests[0]
+	if first.Content != "Plan sprint; review, triage" || first.Description != "Agenda\nin the doc\n\nhttps://todoist.com/showTask?id=1" ||
+		!reflect.DeepEqual(first.Labels, []string{"work", "team"}) || *first.Priority != todoist.PriorityUrgent ||
+		first.DueString != "every monday starting 2024-01-08" {
+		t.Errorf("Unexpected request %+v", first)
+	}
+	if requests[1].DueDatetime != "2024-01-09T14:30:00Z" || requests[1].Duration == nil || requests[1].Duration.Amount != 45 {
+		t.Errorf("Unexpected request %+v", requests[1])
+	}
+}
+
+func TestImport(t *testing.T) {
+	var added []map[string]interface{}
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/tasks" {
+			http.NotFound(rw, r)
+			return
+		}
+		var body map[string]interface{}
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		added = append(added, body)
+		_ = json.NewEncoder(rw).Encode(map[string]interface{}{"id": "1", "content": body["content"]})
+	}))
+	defer server.Close()
+	api := todoist.New("testing-token", todoist.OptionAPIURL(server.URL+"/"))
+
+	calendar := "BEGIN:VCALENDAR\r\nBEGIN:VTODO\r\nSUMMARY:Taxes\r\nDUE;VALUE=DATE:20240430\r\nEND:VTODO\r\nEND:VCALENDAR\r\n"
+	tasks, err := Import(context.Background(), api, strings.NewReader(calendar), ImportOptions{ProjectId: "p1", SectionId: "s1"})
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	if len(tasks) != 1 || tasks[0].Content != "Taxes" {
+		t.Fatalf("Unexpected tasks %+v", tasks)
+	}
+	if added[0]["project_id"] != "p1" || added[0]["section_id"] != "s1" || added[0]["due_date"] != "2024-04-30" {
+		t.Errorf("Unexpected request %v", added[0])
+	}
+
+	_, err = Import(context.Background(), api, strings.NewReader("BEGIN:VTODO\r\nDUE:20240430\r\nEND:VTODO\r\n"), ImportOptions{})
+	if err == nil || !strings.Contains(err.Error(), "missing SUMMARY") {
+		t.Errorf("Unexpected error %v", err)
+	}
+}
@@ -0,0 +1,173 @@
+package todoistics
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+var frequencies = map[string]string{
+	"day":   "DAILY",
+	"week":  "WEEKLY",
+	"month": "MONTHLY",
+	"year":  "YEARLY",
+}
+
+var frequencyUnits = map[string]string{
+	"DAILY":   "day",
+	"WEEKLY":  "week",
+	"MONTHLY": "month",
+	"YEARLY":  "year",
+}
+
+var adverbs = map[string]string{
+	"daily":    "DAILY",
+	"weekly":   "WEEKLY",
+	"monthly":  "MONTHLY",
+	"yearly":   "YEARLY",
+	"annually": "YEARLY",
+}
+
+// weekdays maps the English day names and their abbreviations to RRULE days.
+var weekdays = map[string]string{
+	"monday": "MO", "mon": "MO",
+	"tuesday": "TU", "tue": "TU", "tues": "TU",
+	"wednesday": "WE", "wed": "WE",
+	"thursday": "TH", "thu": "TH", "thurs": "TH",
+	"friday": "FR", "fri": "FR",
+	"saturday": "SA", "sat": "SA",
+	"sunday": "SU", "sun": "SU",
+}
+
+var weekdayNames = map[string]string{
+	"MO": "monday", "TU": "tuesday", "WE": "wednesday", "TH": "thursday",
+	"FR": "friday", "SA": "saturday", "SU": "sunday",
+}
+
+// RRule converts the English due string of a recurring task to an RRULE
+// value, e.g. "every 2 weeks" to "FREQ=WEEKLY;INTERVAL=2". It understands
+// "every day", "every N days", "every other week", "daily" and the like,
+// "every weekday" and lists of days such as "every mon, wed and fri". A
+// trailing time, as in "every day at 9am", is ignored since it is part of the
+// start. Other due strings, including the ones counting from completion
+// ("every!"), are not converted.
+func RRule(dueString string) (string, bool) {
+	s := strings.ToLower(strings.TrimSpace(dueString))
+	if i := strings.Index(s, " at "); i >= 0 {
+		s = s[:i]
+	}
+	if frequency, ok := adverbs[s]; ok {
+		return "FREQ=" + frequency, true
+	}
+
+	rest, ok := strings.CutPrefix(s, "every ")
+	if !ok {
+		return "", false
+	}
+	fields := strings.Fields(rest)
+	interval := 1
+	if len(fields) == 2 {
+		if fields[0] == "other" {
+			interval = 2
+		} else if n, err := strconv.Atoi(fields[0]); err == nil && n > 0 {
+			interval = n
+		} else {
+			fields = nil
+		}
+		if fields != nil {
+			fields = fields[1:]
+		}
+	}
+	if len(fields) == 1 {
+		if frequency, ok := frequencies[strings.TrimSuffix(fields[0], "s")]; ok {
+			if interval == 1 {
+				return "FREQ=" + frequency, true
+			}
+			return fmt.Sprintf("FREQ=%s;INTERVAL=%d", frequency, interval), true
+		}
+	}
+	if rest == "weekday" || rest == "workday" {
+		return "FREQ=WEEKLY;BYDAY=MO,TU,WE,TH,FR", true
+	}
+
+	var days []string
+	for _, name := range strings.FieldsFunc(strings.ReplaceAll(rest, " and ", ","), func(r rune) bool {
+		return r == ',' || r == ' '
+	}) {
+		day, ok := weekdays[name]
+		if !ok {
+			return "", false
+		}
+		days = append(days, day)
+	}
+	if len(days) == 0 {
+		return "", false
+	}
+	return "FREQ=WEEKLY;BYDAY=" + strings.Join(days, ","), true
+}
+
+// DueString converts an RRULE value to an English due string Todoist
+// understands, the reverse of RRule. UNTIL is kept as "until"; rules using
+// COUNT, positional days or other BY parts are not converted.
+func DueString(rrule string) (string, bool) {
+	var frequency, byDay, until string
+	interval := 1
+	for _, part := range strings.Split(strings.ToUpper(rrule), ";") {
+		name, value, _ := strings.Cut(part, "=")
+		switch name {
+		case "FREQ":
+			frequency = value
+		case "INTERVAL":
+			n, err := strconv.Atoi(value)
+			if err != nil || n < 1 {
+				return "", false
+			}
+			interval = n
+		case "BYDAY":
+			byDay = value
+		case "UNTIL":
+			if len(value) < 8 {
+				return "", false
+			}
+			until = value[:4] + "-" + value[4:6] + "-" + value[6:8]
+		case "WKST":
+		default:
+			return "", false
+		}
+	}
+
+	unit, ok := frequencyUnits[frequency]
+	if !ok {
+		return "", false
+	}
+	var s string
+	switch {
+	case byDay != "":
+		if frequency != "WEEKLY" || interval != 1 {
+			return "", false
+		}
+		var names []string
+		for _, day := range strings.Split(byDay, ",") {
+			name, ok := weekdayNames[day]
+			if !ok {
+				return "", false
+			}
+			names = append(names, name)
+		}
+		if byDay == "MO,TU,WE,TH,FR" {
+			s = "every weekday"
+		} else {
+			s = "every " + strings.Join(names, ", ")
+		}
+	case interval == 1:
+		s = "every " + unit
+	case interval == 2:
+		s = "every other " + unit
+	default:
+		s = fmt.Sprintf("every %d %ss", interval, unit)
+	}
+	if until != "" {
+		s += " until " + until
+	}
+	return s, true
+}
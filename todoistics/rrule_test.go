@@ -0,0 +1,62 @@
+package todoistics
+
+import "testing"
+
+func TestRRule(t *testing.T) {
+	for dueString, expected := range map[string]string{
+		"every day":                  "FREQ=DAILY",
+		"Every day at 9am":           "FREQ=DAILY",
+		"daily":                      "FREQ=DAILY",
+		"every 3 days":               "FREQ=DAILY;INTERVAL=3",
+		"every other week":           "FREQ=WEEKLY;INTERVAL=2",
+		"every month":                "FREQ=MONTHLY",
+		"annually":                   "FREQ=YEARLY",
+		"every weekday":              "FREQ=WEEKLY;BYDAY=MO,TU,WE,TH,FR",
+		"every monday":               "FREQ=WEEKLY;BYDAY=MO",
+		"every mon, wed and fri":     "FREQ=WEEKLY;BYDAY=MO,WE,FR",
+		"every tuesday at 14:00":     "FREQ=WEEKLY;BYDAY=TU",
+		"every! 3 days":              "",
+		"every other monday":         "",
+		"every 3rd friday":           "",
+		"every day starting jan 1st": "",
+		"tomorrow":                   "",
+	} {
+		rrule, ok := RRule(dueString)
+		if rrule != expected || ok != (expected != "") {
+			t.Errorf("RRule(%q) = %q, %v, expected %q", dueString, rrule, ok, expected)
+		}
+	}
+}
+
+func TestDueString(t *testing.T) {
+	for rrule, expected := range map[string]string{
+		"FREQ=DAILY":                            "every day",
+		"FREQ=WEEKLY;INTERVAL=2":                "every other week",
+		"FREQ=MONTHLY;INTERVAL=3":               "every 3 months",
+		"FREQ=WEEKLY;BYDAY=MO,TU,WE,TH,FR":      "every weekday",
+		"FREQ=WEEKLY;BYDAY=MO,FR;WKST=MO":       "every monday, friday",
+		"FREQ=DAILY;UNTIL=20240301T000000Z":     "every day until 2024-03-01",
+		"FREQ=DAILY;COUNT=5":                    "",
+		"FREQ=MONTHLY;BYDAY=1MO":                "",
+		"FREQ=HOURLY":                           "",
+		"FREQ=WEEKLY;INTERVAL=2;BYDAY=MO":       "",
+		"FREQ=YEARLY;BYMONTH=1;BYMONTHDAY=1,15": "",
+	} {
+		dueString, ok := DueString(rrule)
+		if dueString != expected || ok != (expected != "") {
+			t.Errorf("DueString(%q) = %q, %v, expected %q", rrule, dueString, ok, expected)
+		}
+	}
+}
+
+func TestRRuleRoundTrip(t *testing.T) {
+	for _, dueString := range []string{"every day", "every other week", "every 3 months", "every weekday"} {
+		rrule, ok := RRule(dueString)
+		if !ok {
+			t.Fatalf("RRule(%q) failed", dueString)
+		}
+		if back, _ := DueString(rrule); back != dueString {
+			t.Errorf("%q became %q", dueString, back)
+		}
+	}
+}
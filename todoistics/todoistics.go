@@ -0,0 +1,275 @@
+// Package todoistics converts tasks to and from iCalendar (RFC 5545).
+//
+// Tasks with a due date become VTODO or VEVENT entries, which calendar apps
+// subscribe to through a Feed:
+//
+//	http.Handle("/calendar.ics", todoistics.NewFeed(api,
+//		todoist.GetActiveTasksRequest{Filter: "today | overdue"},
+//		todoistics.Options{Name: "Todoist"}))
+//
+// Import adds the entries of an .ics file as tasks.
+package todoistics
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/volyanyk/todoist"
+)
+
+// Component is the kind of calendar entry tasks are converted to.
+type Component string
+
+const (
+	ComponentTodo  Component = "VTODO"
+	ComponentEvent Component = "VEVENT"
+)
+
+const (
+	dateFormat     = "20060102"
+	dateTimeFormat = "20060102T150405"
+	utcFormat      = "20060102T150405Z"
+	// lineLength is the longest content line in octets, without CRLF.
+	lineLength = 75
+	uidDomain  = "@todoist.com"
+)
+
+// Options configures the calendar written by Encode.
+type Options struct {
+	Name      string    // Optional, the name calendar apps show
+	Component Component // Optional, defaults to ComponentTodo
+}
+
+// now is the DTSTAMP of the entries.
+var now = time.Now
+
+// Encode writes the tasks having a due date as a calendar. Dates become all
+// day entries, due times with a time zone are written in UTC, and floating
+// due times stay floating so that calendar apps show them in the local time
+// zone. Recurring tasks get an RRULE when RRule understands their due string
+// and are a single entry otherwise.
+//
+// Entries are mapped as follows: content to SUMMARY, description to
+// DESCRIPTION, labels to CATEGORIES, priority to PRIORITY (p1 to 1, p2 to 5,
+// p3 to 9, none for p4), URL to URL and duration to DTEND or DUE. The UID is
+// the task id followed by "@todoist.com".
+func Encode(w io.Writer, tasks []todoist.Task, options Options) error {
+	component := options.Component
+	if component == "" {
+		component = ComponentTodo
+	}
+	if component != ComponentTodo && component != ComponentEvent {
+		return fmt.Errorf("unknown calendar component %q", component)
+	}
+
+	e := &encoder{w: bufio.NewWriter(w)}
+	e.line("BEGIN", "VCALENDAR")
+	e.line("VERSION", "2.0")
+	e.line("PRODID", "-//volyanyk//todoist//EN")
+	e.line("CALSCALE", "GREGORIAN")
+	if options.Name != "" {
+		e.line("X-WR-CALNAME", escapeText(options.Name))
+	}
+	stamp := now().UTC().Format(utcFormat)
+	for _, task := range tasks {
+		if task.Due == nil {
+			continue
+		}
+		if err := e.entry(task, component, stamp); err != nil {
+			return fmt.Errorf("task %s: %w", task.Id, err)
+		}
+	}
+	e.line("END", "VCALENDAR")
+	if e.err != nil {
+		return e.err
+	}
+	return e.w.Flush()
+}
+
+type encoder struct {
+	w   *bufio.Writer
+	err error
+}
+
+func (e *encoder) entry(task todoist.Task, component Component, stamp string) error {
+	start, end, allDay, err := span(task)
+	if err != nil {
+		return err
+	}
+	rrule := ""
+	if task.Due.IsRecurring {
+		rrule, _ = RRule(task.Due.String)
+	}
+
+	e.line("BEGIN", string(component))
+	e.line("UID", task.Id+uidDomain)
+	e.line("DTSTAMP", stamp)
+	e.line("SUMMARY", escapeText(task.Content))
+	if component == ComponentEvent {
+		e.time("DTSTART", start, allDay)
+		if end.IsZero() && allDay {
+			end = start.AddDate(0, 0, 1)
+		}
+		if !end.IsZero() {
+			e.time("DTEND", end, allDay)
+		}
+	} else {
+		// RRULE requires DTSTART, and DUE must come after it.
+		if !end.IsZero() || rrule != "" {
+			e.time("DTSTART", start, allDay)
+		}
+		if !end.IsZero() {
+			e.time("DUE", end, allDay)
+		} else if rrule == "" {
+			e.time("DUE", start, allDay)
+		}
+	}
+	if rrule != "" {
+		e.line("RRULE", rrule)
+	}
+	if task.Description != "" {
+		e.line("DESCRIPTION", escapeText(task.Description))
+	}
+	if len(task.Labels) > 0 {
+		labels := make([]string, len(task.Labels))
+		for i, label := range task.Labels {
+			labels[i] = escapeText(label)
+		}
+		e.line("CATEGORIES", strings.Join(labels, ","))
+	}
+	if priority := icalPriority(task.Priority); priority > 0 {
+		e.line("PRIORITY", fmt.Sprint(priority))
+	}
+	if task.Url != "" {
+		e.line("URL", task.Url)
+	}
+	e.line("END", string(component))
+	return nil
+}
+
+// span returns the start of a task and, for tasks with a duration, its end.
+// Times are in UTC unless they are floating.
+func span(task todoist.Task) (start time.Time, end time.Time, allDay bool, err error) {
+	due := task.Due
+	if due.Datetime == "" {
+		if start, err = time.Parse("2006-01-02", due.Date); err != nil {
+			return
+		}
+		if task.Duration != nil && task.Duration.Unit == todoist.DurationUnitDay && task.Duration.Amount > 0 {
+			end = start.AddDate(0, 0, task.Duration.Amount)
+		}
+		return start, end, true, nil
+	}
+
+	// Floating times are read in a zone without offset and written without
+	// one.
+	if start, err = due.Time(floating); err != nil {
+		return
+	}
+	if start.Location() != floating {
+		start = start.UTC()
+	}
+	if task.Duration != nil && task.Duration.Duration() > 0 {
+		end = start.Add(task.Duration.Duration())
+	}
+	return start, end, false, nil
+}
+
+// floating marks times without a time zone.
+var floating = time.FixedZone("floating", 0)
+
+func (e *encoder) time(name string, t time.Time, allDay bool) {
+	switch {
+	case allDay:
+		e.line(name+";VALUE=DATE", t.Format(dateFormat))
+	case t.Location() == floating:
+		e.line(name, t.Format(dateTimeFormat))
+	default:
+		e.line(name, t.UTC().Format(utcFormat))
+	}
+}
+
+// line writes a content line folded after lineLength octets, without
+// splitting UTF-8 sequences.
+func (e *encoder) line(name string, value string) {
+	if e.err != nil {
+		return
+	}
+	line := name + ":" + value
+	limit := lineLength
+	for len(line) > limit {
+		cut := limit
+		for cut > 0 && !isRuneStart(line[cut]) {
+			cut--
+		}
+		if _, e.err = e.w.WriteString(line[:cut] + "\r\n "); e.err != nil {
+			return
+		}
+		line = line[cut:]
+		// the leading space of continuation lines counts
+		limit = lineLength - 1
+	}
+	_, e.err = e.w.WriteString(line + "\r\n")
+}
+
+func isRuneStart(b byte) bool {
+	return b&0xC0 != 0x80
+}
+
+func escapeText(s string) string {
+	return strings.NewReplacer(
+		`\`, `\\`,
+		";", `\;`,
+		",", `\,`,
+		"\r\n", `\n`,
+		"\n", `\n`,
+	).Replace(s)
+}
+
+func unescapeText(s string) string {
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		if s[i] != '\\' || i == len(s)-1 {
+			b.WriteByte(s[i])
+			continue
+		}
+		i++
+		switch s[i] {
+		case 'n', 'N':
+			b.WriteByte('\n')
+		default:
+			b.WriteByte(s[i])
+		}
+	}
+	return b.String()
+}
+
+// icalPriority maps p1 to 1, p2 to 5 and p3 to 9, the high, medium and low
+// priorities of RFC 5545. p4 is undefined, 0.
+func icalPriority(priority todoist.Priority) int {
+	switch priority {
+	case todoist.PriorityUrgent:
+		return 1
+	case todoist.PriorityHigh:
+		return 5
+	case todoist.PriorityMedium:
+		return 9
+	}
+	return 0
+}
+
+// taskPriority is the reverse of icalPriority.
+func taskPriority(priority int) todoist.Priority {
+	switch {
+	case priority >= 1 && priority <= 4:
+		return todoist.PriorityUrgent
+	case priority == 5:
+		return todoist.PriorityHigh
+	case priority >= 6 && priority <= 9:
+		return todoist.PriorityMedium
+	}
+	return todoist.PriorityNormal
+}
@@ -0,0 +1,168 @@
+package todoistics
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/volyanyk/todoist"
+)
+
+func init() {
+	now = func() time.Time { return time.Date(2024, 1, 5, 8, 0, 0, 0, time.UTC) }
+}
+
+func getTestTasks() []todoist.Task {
+	return []todoist.Task{
+		{
+			Id:          "1",
+			Content:     "Plan sprint; review, triage",
+			Description: "Agenda\nin the doc",
+			Labels:      []string{"work", "team"},
+			Priority:    todoist.PriorityUrgent,
+			Url:         "https://todoist.com/showTask?id=1",
+			Due:         &todoist.Due{Date: "2024-01-08", IsRecurring: true, String: "every monday"},
+		},
+		{
+			Id:       "2",
+			Content:  "Dentist",
+			Priority: todoist.PriorityHigh,
+			Due:      &todoist.Due{Date: "2024-01-09", Datetime: "2024-01-09T14:30:00Z"},
+			Duration: &todoist.TaskDuration{Amount: 45, Unit: todoist.DurationUnitMinute},
+		},
+		{
+			Id:       "3",
+			Content:  "Call back",
+			Priority: todoist.PriorityNormal,
+			Due:      &todoist.Due{Date: "2024-01-10", Datetime: "2024-01-10T09:00:00"},
+		},
+		{Id: "4", Content: "Someday"},
+	}
+}
+
+func encode(t *testing.T, tasks []todoist.Task, options Options) string {
+	t.Helper()
+	var b bytes.Buffer
+	if err := Encode(&b, tasks, options); err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	return b.String()
+}
+
+func TestEncodeTodo(t *testing.T) {
+	expected := strings.Join([]string{
+		"BEGIN:VCALENDAR",
+		"VERSION:2.0",
+		"PRODID:-//volyanyk//todoist//EN",
+		"CALSCALE:GREGORIAN",
+		"X-WR-CALNAME:Work\\, mostly",
+		"BEGIN:VTODO",
+		"UID:1@todoist.com",
+		"DTSTAMP:20240105T080000Z",
+		"SUMMARY:Plan sprint\\; review\\, triage",
+		"DTSTART;VALUE=DATE:20240108",
+		"RRULE:FREQ=WEEKLY;BYDAY=MO",
+		"DESCRIPTION:Agenda\\nin the doc",
+		"CATEGORIES:work,team",
+		"PRIORITY:1",
+		"URL:https://todoist.com/showTask?id=1",
+		"END:VTODO",
+		"BEGIN:VTODO",
+		"UID:2@todoist.com",
+		"DTSTAMP:20240105T080000Z",
+		"SUMMARY:Dentist",
+		"DTSTART:20240109T143000Z",
+		"DUE:20240109T151500Z",
+		"PRIORITY:5",
+		"END:VTODO",
+		"BEGIN:VTODO",
+		"UID:3@todoist.com",
+		"DTSTAMP:20240105T080000Z",
+		"SUMMARY:Call back",
+		"DUE:20240110T090000",
+		"END:VTODO",
+		"END:VCALENDAR",
+		"",
+	}, "\r\n")
+
+	if actual := encode(t, getTestTasks(), Options{Name: "Work, mostly"}); actual != expected {
+		t.Errorf("Unexpected calendar:\n%s", actual)
+	}
+}
+
+func TestEncodeEvent(t *testing.T) {
+	actual := encode(t, getTestTasks()[:3], Options{Component: ComponentEvent})
+	for _, line := range []string{
+		"BEGIN:VEVENT\r\nUID:1@todoist.com",
+		"DTSTART;VALUE=DATE:20240108\r\nDTEND;VALUE=DATE:20240109\r\nRRULE:FREQ=WEEKLY;BYDAY=MO\r\n",
+		"DTSTART:20240109T143000Z\r\nDTEND:20240109T151500Z\r\n",
+		"DTSTART:20240110T090000\r\nEND:VEVENT\r\n",
+	} {
+		if !strings.Contains(actual, line) {
+			t.Errorf("Missing %q in:\n%s", line, actual)
+		}
+	}
+	if strings.Contains(actual, "VTODO") {
+		t.Errorf("Unexpected VTODO in:\n%s", actual)
+	}
+}
+
+func TestEncodeFoldsLongLines(t *testing.T) {
+	task := todoist.Task{Id: "1", Content: strings.Repeat("é", 100), Due: &todoist.Due{Date: "2024-01-08"}}
+	actual := encode(t, []todoist.Task{task}, Options{})
+	for _, line := range strings.Split(actual, "\r\n") {
+		if len(line) > lineLength {
+			t.Errorf("Line of %d octets: %q", len(line), line)
+		}
+	}
+
+	requests, err := Decode(strings.NewReader(actual), time.UTC)
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	if len(requests) != 1 || requests[0].Content != task.Content {
+		t.Errorf("Unexpected requests %+v", requests)
+	}
+}
+
+func TestFeed(t *testing.T) {
+	var query string
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		query = r.URL.Query().Get("filter")
+		_, _ = rw.Write([]byte(`[{"id":"1","content":"Today","due":{"date":"2024-01-05"}},{"id":"2","content":"Undated"}]`))
+	}))
+	defer server.Close()
+	api := todoist.New("testing-token", todoist.OptionAPIURL(server.URL+"/"))
+
+	feed := httptest.NewServer(NewFeed(api, todoist.GetActiveTasksRequest{Filter: "today"}, Options{Name: "Today"}))
+	defer feed.Close()
+
+	resp, err := http.Get(feed.URL)
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	defer resp.Body.Close()
+	body, _ := io.ReadAll(resp.Body)
+	if resp.StatusCode != http.StatusOK || resp.Header.Get("Content-Type") != "text/calendar; charset=utf-8" {
+		t.Fatalf("Unexpected response %s %s", resp.Status, resp.Header.Get("Content-Type"))
+	}
+	if query != "today" {
+		t.Errorf("Unexpected filter %q", query)
+	}
+	if !strings.Contains(string(body), "SUMMARY:Today") || strings.Contains(string(body), "Undated") {
+		t.Errorf("Unexpected calendar:\n%s", body)
+	}
+
+	resp, err = http.Post(feed.URL, "text/calendar", nil)
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusMethodNotAllowed {
+		t.Errorf("Unexpected status %s", resp.Status)
+	}
+}
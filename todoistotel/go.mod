@@ -0,0 +1,24 @@
+module github.com/volyanyk/todoist/todoistotel
+
+go 1.21
+
+require (
+	github.com/volyanyk/todoist v0.0.0-20261019171810-1eeb2a1c2ca9
+	go.opentelemetry.io/otel v1.28.0
+	go.opentelemetry.io/otel/metric v1.28.0
+	go.opentelemetry.io/otel/sdk v1.28.0
+	go.opentelemetry.io/otel/sdk/metric v1.28.0
+	go.opentelemetry.io/otel/trace v1.28.0
+)
+
+require (
+	github.com/go-logr/logr v1.4.2 // indirect
+	github.com/go-logr/stdr v1.2.2 // indirect
+	github.com/google/uuid v1.6.0 // indirect
+	golang.org/x/sys v0.21.0 // indirect
+)
+
+// The replace only applies when building inside this repository, so that the
+// module is developed against the root module next to it. Users get the
+// version required above.
+replace github.com/volyanyk/todoist => ../
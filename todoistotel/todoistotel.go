@@ -0,0 +1,164 @@
+// Package todoistotel instruments a todoist.Client with OpenTelemetry.
+//
+// It provides an interceptor creating one client span per API call, named
+// after the client method (e.g. "todoist.GetActiveTasks"), together with
+// request count, latency and rate limiting metrics:
+//
+//	api := todoist.New(token, todoist.OptionInterceptors(todoistotel.NewInterceptor()))
+package todoistotel
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/volyanyk/todoist"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const instrumentationName = "github.com/volyanyk/todoist/todoistotel"
+
+const (
+	OperationKey     = attribute.Key("todoist.operation")
+	ResourceKey      = attribute.Key("todoist.resource")
+	ResourceIdKey    = attribute.Key("todoist.resource_id")
+	RetryCountKey    = attribute.Key("todoist.retry_count")
+	RequestIdKey     = attribute.Key("todoist.request_id")
+	RetryAfterKey    = attribute.Key("todoist.retry_after")
+	MethodKey        = attribute.Key("http.request.method")
+	StatusCodeKey    = attribute.Key("http.response.status_code")
+	ServerAddressKey = attribute.Key("server.address")
+	ErrorTypeKey     = attribute.Key("error.type")
+)
+
+type config struct {
+	tracerProvider trace.TracerProvider
+	meterProvider  metric.MeterProvider
+}
+
+type Option func(*config)
+
+// WithTracerProvider sets the tracer provider, otel.GetTracerProvider() by
+// default.
+func WithTracerProvider(provider trace.TracerProvider) Option {
+	return func(c *config) { c.tracerProvider = provider }
+}
+
+// WithMeterProvider sets the meter provider, otel.GetMeterProvider() by
+// default.
+func WithMeterProvider(provider metric.MeterProvider) Option {
+	return func(c *config) { c.meterProvider = provider }
+}
+
+type instruments struct {
+	tracer      trace.Tracer
+	requests    metric.Int64Counter
+	duration    metric.Float64Histogram
+	rateLimited metric.Int64Counter
+}
+
+// NewInterceptor returns the interceptor recording spans and metrics. It
+// should be registered first so that its span covers the other interceptors.
+// Errors creating instruments are reported to otel.Handle and the affected
+// instruments are replaced by no-ops.
+func NewInterceptor(options ...Option) todoist.Interceptor {
+	cfg := config{
+		tracerProvider: otel.GetTracerProvider(),
+		meterProvider:  otel.GetMeterProvider(),
+	}
+	for _, option := range options {
+		option(&cfg)
+	}
+
+	i := newInstruments(cfg)
+	return i.intercept
+}
+
+func newInstruments(cfg config) *instruments {
+	meter := cfg.meterProvider.Meter(instrumentationName)
+	i := &instruments{
+		tracer: cfg.tracerProvider.Tracer(instrumentationName),
+	}
+
+	var err error
+	i.requests, err = meter.Int64Counter("todoist.client.requests",
+		metric.WithDescription("Number of Todoist API calls."),
+		metric.WithUnit("{request}"))
+	otel.Handle(err)
+	i.duration, err = meter.Float64Histogram("todoist.client.request.duration",
+		metric.WithDescription("Duration of Todoist API calls."),
+		metric.WithUnit("s"))
+	otel.Handle(err)
+	i.rateLimited, err = meter.Int64Counter("todoist.client.rate_limited",
+		metric.WithDescription("Number of Todoist API calls rejected by rate limiting."),
+		metric.WithUnit("{request}"))
+	otel.Handle(err)
+
+	return i
+}
+
+func (i *instruments) intercept(req *http.Request, next todoist.Handler) (*http.Response, error) {
+	operation, _ := todoist.OperationFromContext(req.Context())
+	attributes := []attribute.KeyValue{
+		OperationKey.String(operation.Name),
+		ResourceKey.String(operation.Resource),
+		MethodKey.String(req.Method),
+		ServerAddressKey.String(req.URL.Hostname()),
+		RetryCountKey.Int(operation.Retry),
+		RequestIdKey.String(req.Header.Get("X-Request-ID")),
+	}
+	if operation.ResourceId != "" {
+		attributes = append(attributes, ResourceIdKey.String(operation.ResourceId))
+	}
+
+	ctx, span := i.tracer.Start(req.Context(), "todoist."+operation.Name,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(attributes...))
+	defer span.End()
+
+	start := time.Now()
+	resp, err := next(req.WithContext(ctx))
+	elapsed := time.Since(start)
+
+	metricAttributes := []attribute.KeyValue{
+		OperationKey.String(operation.Name),
+		MethodKey.String(req.Method),
+	}
+	if resp != nil {
+		span.SetAttributes(StatusCodeKey.Int(resp.StatusCode))
+		metricAttributes = append(metricAttributes, StatusCodeKey.Int(resp.StatusCode))
+	}
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		metricAttributes = append(metricAttributes, ErrorTypeKey.String(errorType(err)))
+
+		var rateLimited *todoist.RateLimitedError
+		if errors.As(err, &rateLimited) {
+			span.SetAttributes(RetryAfterKey.Float64(rateLimited.RetryAfter.Seconds()))
+			i.rateLimited.Add(ctx, 1, metric.WithAttributes(OperationKey.String(operation.Name)))
+		}
+	}
+
+	i.requests.Add(ctx, 1, metric.WithAttributes(metricAttributes...))
+	i.duration.Record(ctx, elapsed.Seconds(), metric.WithAttributes(metricAttributes...))
+
+	return resp, err
+}
+
+func errorType(err error) string {
+	var rateLimited *todoist.RateLimitedError
+	var statusCode todoist.StatusCodeError
+	switch {
+	case errors.As(err, &rateLimited):
+		return "rate_limited"
+	case errors.As(err, &statusCode):
+		return "status_code"
+	default:
+		return "transport"
+	}
+}
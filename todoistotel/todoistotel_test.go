@@ -0,0 +1,123 @@
+package todoistotel
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/volyanyk/todoist"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+func newTestClient(t *testing.T, handler http.HandlerFunc) (*todoist.Client, *tracetest.InMemoryExporter, *sdkmetric.ManualReader) {
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+
+	exporter := tracetest.NewInMemoryExporter()
+	tracerProvider := sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter))
+	reader := sdkmetric.NewManualReader()
+	meterProvider := sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader))
+
+	api := todoist.New("testing-token",
+		todoist.OptionAPIURL(server.URL+"/"),
+		todoist.OptionInterceptors(NewInterceptor(
+			WithTracerProvider(tracerProvider),
+			WithMeterProvider(meterProvider))))
+	return api, exporter, reader
+}
+
+func TestSpanPerCall(t *testing.T) {
+	api, exporter, reader := newTestClient(t, func(rw http.ResponseWriter, r *http.Request) {
+		_, _ = rw.Write([]byte(`{"id":"42"}`))
+	})
+
+	if _, err := api.GetActiveTaskById("42"); err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+
+	spans := exporter.GetSpans()
+	if len(spans) != 1 {
+		t.Fatalf("Expected one span, got %d", len(spans))
+	}
+	span := spans[0]
+	if span.Name != "todoist.GetActiveTaskById" {
+		t.Errorf("Unexpected span name %q", span.Name)
+	}
+	attributes := attribute.NewSet(span.Attributes...)
+	for key, expected := range map[attribute.Key]attribute.Value{
+		ResourceKey:   attribute.StringValue("tasks"),
+		ResourceIdKey: attribute.StringValue("42"),
+		StatusCodeKey: attribute.IntValue(http.StatusOK),
+		RetryCountKey: attribute.IntValue(0),
+	} {
+		if value, ok := attributes.Value(key); !ok || value != expected {
+			t.Errorf("Unexpected %s: %v", key, value.Emit())
+		}
+	}
+	if value, ok := attributes.Value(RequestIdKey); !ok || value.AsString() == "" {
+		t.Errorf("Missing request id")
+	}
+
+	metrics := collect(t, reader)
+	if count := sumOf(metrics, "todoist.client.requests"); count != 1 {
+		t.Errorf("Unexpected request count %d", count)
+	}
+	if _, ok := metrics["todoist.client.request.duration"]; !ok {
+		t.Errorf("Missing duration histogram")
+	}
+}
+
+func TestRateLimitedCall(t *testing.T) {
+	api, exporter, reader := newTestClient(t, func(rw http.ResponseWriter, r *http.Request) {
+		rw.Header().Set("Retry-After", "30")
+		rw.WriteHeader(http.StatusTooManyRequests)
+	})
+
+	if _, err := api.GetProjects(); err == nil {
+		t.Fatal("Expected an error")
+	}
+
+	span := exporter.GetSpans()[0]
+	if span.Name != "todoist.GetProjects" || span.Status.Code != codes.Error {
+		t.Errorf("Unexpected span %q with status %v", span.Name, span.Status)
+	}
+	spanAttributes := attribute.NewSet(span.Attributes...)
+	if value, ok := spanAttributes.Value(RetryAfterKey); !ok || value.AsFloat64() != 30 {
+		t.Errorf("Unexpected retry after: %v", value.Emit())
+	}
+	if count := sumOf(collect(t, reader), "todoist.client.rate_limited"); count != 1 {
+		t.Errorf("Unexpected rate limited count %d", count)
+	}
+}
+
+func collect(t *testing.T, reader *sdkmetric.ManualReader) map[string]metricdata.Aggregation {
+	data := metricdata.ResourceMetrics{}
+	if err := reader.Collect(context.Background(), &data); err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	metrics := map[string]metricdata.Aggregation{}
+	for _, scope := range data.ScopeMetrics {
+		for _, m := range scope.Metrics {
+			metrics[m.Name] = m.Data
+		}
+	}
+	return metrics
+}
+
+func sumOf(metrics map[string]metricdata.Aggregation, name string) int64 {
+	sum, ok := metrics[name].(metricdata.Sum[int64])
+	if !ok {
+		return 0
+	}
+	total := int64(0)
+	for _, point := range sum.DataPoints {
+		total += point.Value
+	}
+	return total
+}
@@ -0,0 +1,146 @@
+package todoistsqlite
+
+import (
+	"context"
+	"database/sql"
+)
+
+// Schema creates the tables of the mirror. It only adds what is missing, so
+// it can be run against an existing mirror.
+//
+// Identifiers are the ones of the API. Booleans are stored as 0 and 1, dates
+// and times as the ISO 8601 text the API returns, which the SQLite date
+// functions understand. Priorities keep the API values, 4 being the "p1" of
+// the apps.
+//
+// Tables:
+//
+//   - projects: the active projects.
+//   - sections: the active sections of the active projects.
+//   - tasks: the active tasks and the ones completed since the mirror first
+//     saw them. A task that disappears from the active ones is kept with
+//     is_completed set when it has a completion, and removed otherwise.
+//   - task_labels: the label names of the tasks, one row per task and label.
+//     Shared labels only appear here, not in labels.
+//   - completions: one row per completion, including every completion of a
+//     recurring task. Completions of tasks the mirror never saw active do not
+//     join any task_labels.
+//   - labels: the personal labels.
+//   - comments: the comments of tasks and projects.
+//   - collaborators: the collaborators of the shared projects.
+//   - sync_state: bookkeeping of the incremental updates.
+//
+// Tasks completed per label per week:
+//
+//	SELECT l.label, strftime('%Y-%W', c.completed_at) AS week, count(*)
+//	FROM completions c JOIN task_labels l ON l.task_id = c.task_id
+//	GROUP BY l.label, week ORDER BY week, l.label
+const Schema = `
+CREATE TABLE IF NOT EXISTS projects (
+	id               TEXT PRIMARY KEY,
+	parent_id        TEXT,
+	name             TEXT NOT NULL,
+	color            TEXT,
+	"order"          INTEGER,
+	comment_count    INTEGER NOT NULL DEFAULT 0,
+	is_shared        INTEGER NOT NULL DEFAULT 0,
+	is_favorite      INTEGER NOT NULL DEFAULT 0,
+	is_inbox_project INTEGER NOT NULL DEFAULT 0,
+	is_team_inbox    INTEGER NOT NULL DEFAULT 0,
+	view_style       TEXT,
+	url              TEXT
+);
+
+CREATE TABLE IF NOT EXISTS sections (
+	id         TEXT PRIMARY KEY,
+	project_id TEXT NOT NULL,
+	name       TEXT NOT NULL,
+	"order"    INTEGER
+);
+CREATE INDEX IF NOT EXISTS sections_project_id ON sections (project_id);
+
+CREATE TABLE IF NOT EXISTS tasks (
+	id               TEXT PRIMARY KEY,
+	project_id       TEXT NOT NULL,
+	section_id       TEXT,
+	parent_id        TEXT,
+	content          TEXT NOT NULL,
+	description      TEXT NOT NULL DEFAULT '',
+	priority         INTEGER NOT NULL DEFAULT 1,
+	"order"          INTEGER,
+	comment_count    INTEGER NOT NULL DEFAULT 0,
+	creator_id       TEXT,
+	assigner_id      TEXT,
+	assignee_id      TEXT,
+	created_at       TEXT,
+	due_date         TEXT,
+	due_datetime     TEXT,
+	due_string       TEXT,
+	due_timezone     TEXT,
+	due_is_recurring INTEGER NOT NULL DEFAULT 0,
+	duration_amount  INTEGER,
+	duration_unit    TEXT,
+	deadline_date    TEXT,
+	url              TEXT,
+	is_completed     INTEGER NOT NULL DEFAULT 0,
+	completed_at     TEXT
+);
+CREATE INDEX IF NOT EXISTS tasks_project_id ON tasks (project_id);
+
+CREATE TABLE IF NOT EXISTS task_labels (
+	task_id TEXT NOT NULL,
+	label   TEXT NOT NULL,
+	PRIMARY KEY (task_id, label)
+);
+CREATE INDEX IF NOT EXISTS task_labels_label ON task_labels (label);
+
+CREATE TABLE IF NOT EXISTS completions (
+	id           TEXT PRIMARY KEY,
+	task_id      TEXT NOT NULL,
+	project_id   TEXT NOT NULL,
+	section_id   TEXT,
+	user_id      TEXT,
+	content      TEXT NOT NULL,
+	completed_at TEXT NOT NULL
+);
+CREATE INDEX IF NOT EXISTS completions_task_id ON completions (task_id);
+CREATE INDEX IF NOT EXISTS completions_completed_at ON completions (completed_at);
+
+CREATE TABLE IF NOT EXISTS labels (
+	id          TEXT PRIMARY KEY,
+	name        TEXT NOT NULL,
+	color       TEXT,
+	"order"     INTEGER,
+	is_favorite INTEGER NOT NULL DEFAULT 0
+);
+
+CREATE TABLE IF NOT EXISTS comments (
+	id              TEXT PRIMARY KEY,
+	task_id         TEXT,
+	project_id      TEXT,
+	content         TEXT NOT NULL,
+	posted_at       TEXT,
+	attachment_name TEXT,
+	attachment_url  TEXT
+);
+CREATE INDEX IF NOT EXISTS comments_task_id ON comments (task_id);
+
+CREATE TABLE IF NOT EXISTS collaborators (
+	project_id TEXT NOT NULL,
+	id         TEXT NOT NULL,
+	name       TEXT,
+	email      TEXT,
+	PRIMARY KEY (project_id, id)
+);
+
+CREATE TABLE IF NOT EXISTS sync_state (
+	key   TEXT PRIMARY KEY,
+	value TEXT NOT NULL
+);
+`
+
+// CreateSchema runs Schema.
+func CreateSchema(ctx context.Context, db *sql.DB) error {
+	_, err := db.ExecContext(ctx, Schema)
+	return err
+}
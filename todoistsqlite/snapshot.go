@@ -0,0 +1,378 @@
+package todoistsqlite
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/volyanyk/todoist"
+)
+
+// snapshot is the data fetched by one Sync.
+type snapshot struct {
+	projects      []todoist.Project
+	sections      []todoist.Section
+	collaborators map[string][]todoist.Collaborator
+	tasks         []todoist.Task
+	completions   []todoist.CompletedTask
+	labels        []todoist.Label
+	// taskComments and projectComments hold the comments of the tasks and
+	// projects whose comment count changed.
+	taskComments    map[string][]todoist.Comment
+	projectComments map[string][]todoist.Comment
+}
+
+func (m *Mirror) fetch(ctx context.Context, since string, taskComments map[string]int, projectComments map[string]int) (*snapshot, error) {
+	s := &snapshot{
+		collaborators:   map[string][]todoist.Collaborator{},
+		taskComments:    map[string][]todoist.Comment{},
+		projectComments: map[string][]todoist.Comment{},
+	}
+	var err error
+
+	if s.projects, err = collect(m.api.IterateProjectsContext(ctx)); err != nil {
+		return nil, err
+	}
+	for _, project := range s.projects {
+		sections, err := m.api.GetSectionsByProjectIdContext(project.ID, ctx)
+		if err != nil {
+			return nil, err
+		}
+		s.sections = append(s.sections, *sections...)
+
+		if project.IsShared {
+			collaborators, err := m.api.GetProjectCollaboratorsContext(project.ID, ctx)
+			if err != nil {
+				return nil, err
+			}
+			s.collaborators[project.ID] = *collaborators
+		}
+
+		if project.CommentCount != projectComments[project.ID] {
+			if s.projectComments[project.ID], err = collect(m.api.IterateAllCommentsContext(project.ID, "", ctx)); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	if s.tasks, err = collect(m.api.IterateActiveTasksContext(todoist.GetActiveTasksRequest{}, ctx)); err != nil {
+		return nil, err
+	}
+	for _, task := range s.tasks {
+		if task.CommentCount != taskComments[task.Id] {
+			if s.taskComments[task.Id], err = collect(m.api.IterateAllCommentsContext("", task.Id, ctx)); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	if s.labels, err = collect(m.api.IterateLabelsContext(ctx)); err != nil {
+		return nil, err
+	}
+
+	for offset := 0; ; offset += completedPageSize {
+		page, err := m.api.GetCompletedTasksContext(todoist.GetCompletedTasksRequest{
+			Limit:  completedPageSize,
+			Offset: offset,
+			Since:  since,
+		}, ctx)
+		if err != nil {
+			return nil, err
+		}
+		s.completions = append(s.completions, *page...)
+		if len(*page) < completedPageSize {
+			break
+		}
+	}
+	return s, nil
+}
+
+func collect[T any](it *todoist.Iterator[T]) ([]T, error) {
+	defer it.Close()
+	var items []T
+	for it.Next() {
+		items = append(items, it.Value())
+	}
+	return items, it.Err()
+}
+
+func (s *snapshot) write(ctx context.Context, tx *sql.Tx) (*SyncStats, error) {
+	stats := &SyncStats{}
+	for _, step := range []func(context.Context, *sql.Tx, *SyncStats) error{
+		s.writeProjects,
+		s.writeSections,
+		s.writeCollaborators,
+		s.writeLabels,
+		s.writeCompletions,
+		s.writeTasks,
+		s.writeComments,
+	} {
+		if err := step(ctx, tx, stats); err != nil {
+			return nil, err
+		}
+	}
+	return stats, nil
+}
+
+func (s *snapshot) writeProjects(ctx context.Context, tx *sql.Tx, stats *SyncStats) error {
+	keep := map[string]bool{}
+	for _, p := range s.projects {
+		keep[p.ID] = true
+		_, err := tx.ExecContext(ctx,
+			`INSERT INTO projects (id, parent_id, name, color, "order", comment_count, is_shared, is_favorite, is_inbox_project, is_team_inbox, view_style, url)
+			VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+			ON CONFLICT (id) DO UPDATE SET
+				parent_id = excluded.parent_id, name = excluded.name, color = excluded.color,
+				"order" = excluded."order", comment_count = excluded.comment_count,
+				is_shared = excluded.is_shared, is_favorite = excluded.is_favorite,
+				is_inbox_project = excluded.is_inbox_project, is_team_inbox = excluded.is_team_inbox,
+				view_style = excluded.view_style, url = excluded.url`,
+			p.ID, p.ParentId, p.Name, string(p.Color), p.Order, p.CommentCount, p.IsShared, p.IsFavorite,
+			p.IsInboxProject, p.IsTeamInbox, string(p.ViewStyle), p.Url)
+		if err != nil {
+			return err
+		}
+		stats.Projects++
+	}
+	return deleteMissing(ctx, tx, "projects", keep)
+}
+
+func (s *snapshot) writeSections(ctx context.Context, tx *sql.Tx, stats *SyncStats) error {
+	keep := map[string]bool{}
+	for _, section := range s.sections {
+		keep[section.ID] = true
+		_, err := tx.ExecContext(ctx,
+			`INSERT INTO sections (id, project_id, name, "order") VALUES (?, ?, ?, ?)
+			ON CONFLICT (id) DO UPDATE SET
+				project_id = excluded.project_id, name = excluded.name, "order" = excluded."order"`,
+			section.ID, section.ProjectId, section.Name, section.Order)
+		if err != nil {
+			return err
+		}
+		stats.Sections++
+	}
+	return deleteMissing(ctx, tx, "sections", keep)
+}
+
+func (s *snapshot) writeCollaborators(ctx context.Context, tx *sql.Tx, stats *SyncStats) error {
+	if _, err := tx.ExecContext(ctx, `DELETE FROM collaborators`); err != nil {
+		return err
+	}
+	for projectId, collaborators := range s.collaborators {
+		for _, c := range collaborators {
+			_, err := tx.ExecContext(ctx,
+				`INSERT OR REPLACE INTO collaborators (project_id, id, name, email) VALUES (?, ?, ?, ?)`,
+				projectId, c.ID, c.Name, c.Email)
+			if err != nil {
+				return err
+			}
+			stats.Collaborators++
+		}
+	}
+	return nil
+}
+
+func (s *snapshot) writeLabels(ctx context.Context, tx *sql.Tx, stats *SyncStats) error {
+	keep := map[string]bool{}
+	for _, l := range s.labels {
+		keep[l.ID] = true
+		_, err := tx.ExecContext(ctx,
+			`INSERT INTO labels (id, name, color, "order", is_favorite) VALUES (?, ?, ?, ?, ?)
+			ON CONFLICT (id) DO UPDATE SET
+				name = excluded.name, color = excluded.color, "order" = excluded."order",
+				is_favorite = excluded.is_favorite`,
+			l.ID, l.Name, string(l.Color), l.Order, l.IsFavorite)
+		if err != nil {
+			return err
+		}
+		stats.Labels++
+	}
+	return deleteMissing(ctx, tx, "labels", keep)
+}
+
+func (s *snapshot) writeCompletions(ctx context.Context, tx *sql.Tx, stats *SyncStats) error {
+	for _, c := range s.completions {
+		_, err := tx.ExecContext(ctx,
+			`INSERT INTO completions (id, task_id, project_id, section_id, user_id, content, completed_at)
+			VALUES (?, ?, ?, ?, ?, ?, ?)
+			ON CONFLICT (id) DO UPDATE SET
+				project_id = excluded.project_id, section_id = excluded.section_id,
+				content = excluded.content, completed_at = excluded.completed_at`,
+			c.Id, c.TaskId, c.ProjectId, c.SectionId, c.UserId, c.Content, c.CompletedAt)
+		if err != nil {
+			return err
+		}
+		stats.Completions++
+	}
+	return nil
+}
+
+// writeTasks upserts the active tasks, then marks the tasks that are no
+// longer active as completed when they have a completion, and removes the
+// other ones, which were deleted. Completions must be written first.
+func (s *snapshot) writeTasks(ctx context.Context, tx *sql.Tx, stats *SyncStats) error {
+	active := map[string]bool{}
+	for _, t := range s.tasks {
+		active[t.Id] = true
+		var due todoist.Due
+		if t.Due != nil {
+			due = *t.Due
+		}
+		var durationAmount *int
+		var durationUnit *string
+		if t.Duration != nil {
+			unit := string(t.Duration.Unit)
+			durationAmount, durationUnit = &t.Duration.Amount, &unit
+		}
+		var deadline *string
+		if t.Deadline != nil {
+			deadline = &t.Deadline.Date
+		}
+		_, err := tx.ExecContext(ctx,
+			`INSERT INTO tasks (id, project_id, section_id, parent_id, content, description, priority, "order",
+				comment_count, creator_id, assigner_id, assignee_id, created_at, due_date, due_datetime,
+				due_string, due_timezone, due_is_recurring, duration_amount, duration_unit, deadline_date, url,
+				is_completed, completed_at)
+			VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, 0, NULL)
+			ON CONFLICT (id) DO UPDATE SET
+				project_id = excluded.project_id, section_id = excluded.section_id,
+				parent_id = excluded.parent_id, content = excluded.content,
+				description = excluded.description, priority = excluded.priority,
+				"order" = excluded."order", comment_count = excluded.comment_count,
+				creator_id = excluded.creator_id, assigner_id = excluded.assigner_id,
+				assignee_id = excluded.assignee_id, created_at = excluded.created_at,
+				due_date = excluded.due_date, due_datetime = excluded.due_datetime,
+				due_string = excluded.due_string, due_timezone = excluded.due_timezone,
+				due_is_recurring = excluded.due_is_recurring, duration_amount = excluded.duration_amount,
+				duration_unit = excluded.duration_unit, deadline_date = excluded.deadline_date,
+				url = excluded.url, is_completed = 0, completed_at = NULL`,
+			t.Id, t.ProjectId, t.SectionId, t.ParentId, t.Content, t.Description, int(t.Priority), t.Order,
+			t.CommentCount, t.CreatorId, t.AssignerId, t.AssigneeId, t.CreatedAt, nullString(due.Date),
+			nullString(due.Datetime), nullString(due.String), nullString(due.Timezone), due.IsRecurring,
+			durationAmount, durationUnit, deadline, t.Url)
+		if err != nil {
+			return err
+		}
+		if _, err := tx.ExecContext(ctx, `DELETE FROM task_labels WHERE task_id = ?`, t.Id); err != nil {
+			return err
+		}
+		for _, label := range t.Labels {
+			if _, err := tx.ExecContext(ctx, `INSERT OR IGNORE INTO task_labels (task_id, label) VALUES (?, ?)`, t.Id, label); err != nil {
+				return err
+			}
+		}
+		stats.Tasks++
+	}
+
+	gone, err := ids(ctx, tx, `SELECT id FROM tasks WHERE is_completed = 0`)
+	if err != nil {
+		return err
+	}
+	for _, id := range gone {
+		if active[id] {
+			continue
+		}
+		var completedAt sql.NullString
+		err := tx.QueryRowContext(ctx, `SELECT max(completed_at) FROM completions WHERE task_id = ?`, id).Scan(&completedAt)
+		if err != nil {
+			return err
+		}
+		if completedAt.Valid {
+			_, err = tx.ExecContext(ctx, `UPDATE tasks SET is_completed = 1, completed_at = ? WHERE id = ?`, completedAt.String, id)
+		} else {
+			err = deleteTask(ctx, tx, id)
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func deleteTask(ctx context.Context, tx *sql.Tx, id string) error {
+	for _, query := range []string{
+		`DELETE FROM tasks WHERE id = ?`,
+		`DELETE FROM task_labels WHERE task_id = ?`,
+		`DELETE FROM comments WHERE task_id = ?`,
+	} {
+		if _, err := tx.ExecContext(ctx, query, id); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// writeComments replaces the comments of the tasks and projects whose
+// comment count changed, and removes the ones of removed projects.
+func (s *snapshot) writeComments(ctx context.Context, tx *sql.Tx, stats *SyncStats) error {
+	for _, replaced := range []struct {
+		column   string
+		comments map[string][]todoist.Comment
+	}{
+		{"task_id", s.taskComments},
+		{"project_id", s.projectComments},
+	} {
+		for id, comments := range replaced.comments {
+			if _, err := tx.ExecContext(ctx, `DELETE FROM comments WHERE `+replaced.column+` = ?`, id); err != nil {
+				return err
+			}
+			for _, c := range comments {
+				var name, url *string
+				if c.Attachment != nil {
+					name, url = &c.Attachment.FileName, &c.Attachment.FileUrl
+				}
+				_, err := tx.ExecContext(ctx,
+					`INSERT OR REPLACE INTO comments (id, task_id, project_id, content, posted_at, attachment_name, attachment_url)
+					VALUES (?, ?, ?, ?, ?, ?, ?)`,
+					c.Id, c.TaskId, c.ProjectId, c.Content, c.PostedAt, name, url)
+				if err != nil {
+					return err
+				}
+				stats.Comments++
+			}
+		}
+	}
+	_, err := tx.ExecContext(ctx,
+		`DELETE FROM comments WHERE task_id IS NULL AND project_id NOT IN (SELECT id FROM projects)`)
+	return err
+}
+
+// deleteMissing removes the rows of table whose id is not kept.
+func deleteMissing(ctx context.Context, tx *sql.Tx, table string, keep map[string]bool) error {
+	existing, err := ids(ctx, tx, `SELECT id FROM `+table)
+	if err != nil {
+		return err
+	}
+	for _, id := range existing {
+		if keep[id] {
+			continue
+		}
+		if _, err := tx.ExecContext(ctx, `DELETE FROM `+table+` WHERE id = ?`, id); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func ids(ctx context.Context, tx *sql.Tx, query string) ([]string, error) {
+	rows, err := tx.QueryContext(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var ids []string
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		ids = append(ids, id)
+	}
+	return ids, rows.Err()
+}
+
+func nullString(s string) *string {
+	if s == "" {
+		return nil
+	}
+	return &s
+}
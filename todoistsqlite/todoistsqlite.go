@@ -0,0 +1,174 @@
+// Package todoistsqlite mirrors the data of a Todoist account into a SQLite
+// database for ad-hoc SQL reporting. See Schema for the tables.
+//
+//	mirror, err := todoistsqlite.Open(api, "todoist.db")
+//	if err != nil {
+//	}
+//	defer mirror.Close()
+//	stats, err := mirror.Sync(ctx)
+//
+// Later runs of Sync update the mirror in place: completed tasks are fetched
+// since the previous run only, and comments only for the tasks and projects
+// whose comment count changed.
+package todoistsqlite
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/volyanyk/todoist"
+	_ "modernc.org/sqlite"
+)
+
+// completedPageSize is the largest page of completed tasks the API returns.
+const completedPageSize = 200
+
+// completedSinceFormat is the format of the since parameter of completed tasks.
+const completedSinceFormat = "2006-1-2T15:04"
+
+const stateCompletedSince = "completed_since"
+const stateSyncedAt = "synced_at"
+
+// Mirror copies the data of a client into a database.
+type Mirror struct {
+	api *todoist.Client
+	db  *sql.DB
+	now func() time.Time
+}
+
+// SyncStats counts the rows a Sync wrote.
+type SyncStats struct {
+	Projects      int
+	Sections      int
+	Tasks         int
+	Completions   int
+	Labels        int
+	Comments      int
+	Collaborators int
+}
+
+// New creates a mirror writing to db, which may be opened with any SQLite
+// driver supporting upserts (SQLite 3.24 or later).
+func New(api *todoist.Client, db *sql.DB) *Mirror {
+	return &Mirror{api: api, db: db, now: time.Now}
+}
+
+// Open creates a mirror writing to the SQLite database file at path, using
+// the pure Go driver modernc.org/sqlite.
+func Open(api *todoist.Client, path string) (*Mirror, error) {
+	db, err := sql.Open("sqlite", path)
+	if err != nil {
+		return nil, err
+	}
+	// Writes are serialized by SQLite anyway, and a single connection keeps
+	// in-memory databases alive.
+	db.SetMaxOpenConns(1)
+	return New(api, db), nil
+}
+
+// DB returns the database of the mirror.
+func (m *Mirror) DB() *sql.DB {
+	return m.db
+}
+
+// Close closes the database of the mirror.
+func (m *Mirror) Close() error {
+	return m.db.Close()
+}
+
+// Sync fetches the account and writes it to the database in a single
+// transaction, creating the schema first if needed. Nothing is written when
+// any request fails.
+func (m *Mirror) Sync(ctx context.Context) (*SyncStats, error) {
+	if err := CreateSchema(ctx, m.db); err != nil {
+		return nil, err
+	}
+	started := m.now().UTC()
+
+	since, err := m.state(ctx, stateCompletedSince)
+	if err != nil {
+		return nil, err
+	}
+	taskComments, err := m.commentCounts(ctx, "tasks")
+	if err != nil {
+		return nil, err
+	}
+	projectComments, err := m.commentCounts(ctx, "projects")
+	if err != nil {
+		return nil, err
+	}
+
+	s, err := m.fetch(ctx, since, taskComments, projectComments)
+	if err != nil {
+		return nil, err
+	}
+
+	tx, err := m.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	stats, err := s.write(ctx, tx)
+	if err != nil {
+		return nil, err
+	}
+	// Since has a precision of minutes and is inclusive, so completions of
+	// the current minute are fetched again; they are upserted by id.
+	if err := setState(ctx, tx, stateCompletedSince, started.Truncate(time.Minute).Format(completedSinceFormat)); err != nil {
+		return nil, err
+	}
+	if err := setState(ctx, tx, stateSyncedAt, started.Format(time.RFC3339)); err != nil {
+		return nil, err
+	}
+	return stats, tx.Commit()
+}
+
+// LastSync returns the start of the last successful Sync, or the zero time.
+func (m *Mirror) LastSync(ctx context.Context) (time.Time, error) {
+	if err := CreateSchema(ctx, m.db); err != nil {
+		return time.Time{}, err
+	}
+	value, err := m.state(ctx, stateSyncedAt)
+	if err != nil || value == "" {
+		return time.Time{}, err
+	}
+	return time.Parse(time.RFC3339, value)
+}
+
+func (m *Mirror) state(ctx context.Context, key string) (string, error) {
+	var value string
+	err := m.db.QueryRowContext(ctx, `SELECT value FROM sync_state WHERE key = ?`, key).Scan(&value)
+	if errors.Is(err, sql.ErrNoRows) {
+		return "", nil
+	}
+	return value, err
+}
+
+func setState(ctx context.Context, tx *sql.Tx, key string, value string) error {
+	_, err := tx.ExecContext(ctx,
+		`INSERT INTO sync_state (key, value) VALUES (?, ?)
+		ON CONFLICT (key) DO UPDATE SET value = excluded.value`, key, value)
+	return err
+}
+
+// commentCounts returns the comment counts stored for the rows of table.
+func (m *Mirror) commentCounts(ctx context.Context, table string) (map[string]int, error) {
+	rows, err := m.db.QueryContext(ctx, `SELECT id, comment_count FROM `+table)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	counts := map[string]int{}
+	for rows.Next() {
+		var id string
+		var count int
+		if err := rows.Scan(&id, &count); err != nil {
+			return nil, err
+		}
+		counts[id] = count
+	}
+	return counts, rows.Err()
+}
@@ -0,0 +1,276 @@
+package todoistsqlite
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/volyanyk/todoist"
+)
+
+// fakeAccount serves the endpoints read by Sync from mutable fixtures.
+type fakeAccount struct {
+	mu            sync.Mutex
+	projects      []map[string]interface{}
+	sections      map[string][]map[string]interface{}
+	collaborators []map[string]interface{}
+	tasks         []map[string]interface{}
+	labels        []map[string]interface{}
+	comments      map[string][]map[string]interface{}
+	completions   []map[string]interface{}
+	requests      map[string]int
+	since         []string
+}
+
+func newFakeAccount() *fakeAccount {
+	return &fakeAccount{
+		projects: []map[string]interface{}{
+			{"id": "p1", "name": "Inbox", "color": "grey", "is_inbox_project": true, "view_style": "list"},
+			{"id": "p2", "name": "Team", "color": "blue", "is_shared": true, "view_style": "board"},
+		},
+		sections: map[string][]map[string]interface{}{
+			"p2": {{"id": "s1", "project_id": "p2", "name": "Doing", "order": 1}},
+		},
+		collaborators: []map[string]interface{}{
+			{"id": "u1", "name": "Ana", "email": "ana@example.com"},
+		},
+		tasks: []map[string]interface{}{
+			{"id": "t1", "project_id": "p1", "content": "Write report", "priority": 4, "labels": []string{"work"},
+				"due":           map[string]interface{}{"date": "2024-01-08", "string": "every monday", "is_recurring": true},
+				"comment_count": 1},
+			{"id": "t2", "project_id": "p2", "section_id": "s1", "content": "Ship", "priority": 1,
+				"labels": []string{"work", "urgent"}, "duration": map[string]interface{}{"amount": 30, "unit": "minute"}},
+			{"id": "t3", "project_id": "p2", "content": "Dropped", "priority": 1, "labels": []string{}},
+		},
+		labels: []map[string]interface{}{
+			{"id": "l1", "name": "work", "color": "red", "order": 1},
+			{"id": "l2", "name": "urgent", "color": "orange", "order": 2},
+		},
+		comments: map[string][]map[string]interface{}{
+			"task_id=t1": {{"id": "c1", "task_id": "t1", "content": "Draft attached", "posted_at": "2024-01-02T10:00:00Z"}},
+		},
+		completions: []map[string]interface{}{
+			{"id": "k1", "task_id": "t1", "project_id": "p1", "content": "Write report", "completed_at": "2024-01-01T09:00:00.000000Z"},
+		},
+		requests: map[string]int{},
+	}
+}
+
+func (f *fakeAccount) ServeHTTP(rw http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.requests[r.URL.Path]++
+
+	var response interface{}
+	switch r.URL.Path {
+	case "/projects":
+		response = f.projects
+	case "/projects/p2/collaborators":
+		response = f.collaborators
+	case "/sections":
+		response = f.sections[r.URL.Query().Get("project_id")]
+	case "/tasks":
+		response = f.tasks
+	case "/labels":
+		response = f.labels
+	case "/comments":
+		key := "task_id=" + r.URL.Query().Get("task_id")
+		if r.URL.Query().Get("project_id") != "" {
+			key = "project_id=" + r.URL.Query().Get("project_id")
+		}
+		response = f.comments[key]
+	case "/sync/completed/get_all":
+		f.since = append(f.since, r.URL.Query().Get("since"))
+		response = map[string]interface{}{"items": f.completions}
+	default:
+		http.NotFound(rw, r)
+		return
+	}
+	if response == nil {
+		response = []interface{}{}
+	}
+	rw.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(rw).Encode(response)
+}
+
+func newTestMirror(t *testing.T, account *fakeAccount) *Mirror {
+	server := httptest.NewServer(account)
+	t.Cleanup(server.Close)
+
+	api := todoist.New("testing-token",
+		todoist.OptionAPIURL(server.URL+"/"),
+		todoist.OptionSyncAPIURL(server.URL+"/sync/"))
+	mirror, err := Open(api, filepath.Join(t.TempDir(), "todoist.db"))
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	t.Cleanup(func() { _ = mirror.Close() })
+	mirror.now = func() time.Time { return time.Date(2024, 1, 10, 12, 30, 45, 0, time.UTC) }
+	return mirror
+}
+
+func query(t *testing.T, mirror *Mirror, q string) [][]string {
+	t.Helper()
+	rows, err := mirror.DB().Query(q)
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	defer rows.Close()
+	columns, _ := rows.Columns()
+	var result [][]string
+	for rows.Next() {
+		values := make([]cell, len(columns))
+		pointers := make([]interface{}, len(columns))
+		for i := range values {
+			pointers[i] = &values[i]
+		}
+		if err := rows.Scan(pointers...); err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+		row := make([]string, len(columns))
+		for i, value := range values {
+			row[i] = string(value)
+		}
+		result = append(result, row)
+	}
+	if err := rows.Err(); err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	return result
+}
+
+// cell scans any column, NULL being "NULL".
+type cell string
+
+func (s *cell) Scan(value interface{}) error {
+	switch v := value.(type) {
+	case nil:
+		*s = "NULL"
+	case []byte:
+		*s = cell(v)
+	case string:
+		*s = cell(v)
+	default:
+		b, _ := json.Marshal(v)
+		*s = cell(b)
+	}
+	return nil
+}
+
+func TestSync(t *testing.T) {
+	account := newFakeAccount()
+	mirror := newTestMirror(t, account)
+
+	stats, err := mirror.Sync(context.Background())
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	expectedStats := &SyncStats{Projects: 2, Sections: 1, Tasks: 3, Completions: 1, Labels: 2, Comments: 1, Collaborators: 1}
+	if !reflect.DeepEqual(stats, expectedStats) {
+		t.Fatalf("Unexpected stats %+v", stats)
+	}
+
+	for q, expected := range map[string][][]string{
+		`SELECT id, name, is_shared, view_style FROM projects ORDER BY id`: {
+			{"p1", "Inbox", "0", "list"}, {"p2", "Team", "1", "board"}},
+		`SELECT id, section_id, priority, due_date, due_is_recurring, duration_amount, duration_unit FROM tasks ORDER BY id`: {
+			{"t1", "NULL", "4", "2024-01-08", "1", "NULL", "NULL"},
+			{"t2", "s1", "1", "NULL", "0", "30", "minute"},
+			{"t3", "NULL", "1", "NULL", "0", "NULL", "NULL"}},
+		`SELECT task_id, label FROM task_labels ORDER BY task_id, label`: {
+			{"t1", "work"}, {"t2", "urgent"}, {"t2", "work"}},
+		`SELECT id, task_id, content FROM comments`:                  {{"c1", "t1", "Draft attached"}},
+		`SELECT project_id, id, email FROM collaborators`:            {{"p2", "u1", "ana@example.com"}},
+		`SELECT value FROM sync_state WHERE key = 'completed_since'`: {{"2024-1-10T12:30"}},
+	} {
+		if actual := query(t, mirror, q); !reflect.DeepEqual(actual, expected) {
+			t.Errorf("%s: got %v, expected %v", q, actual, expected)
+		}
+	}
+	if account.since[0] != "" {
+		t.Errorf("Unexpected since %q on the first sync", account.since[0])
+	}
+}
+
+func TestSyncIncremental(t *testing.T) {
+	account := newFakeAccount()
+	mirror := newTestMirror(t, account)
+	if _, err := mirror.Sync(context.Background()); err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+
+	// t2 gets completed, t3 deleted, the recurring t1 completed once more,
+	// and the label urgent renamed.
+	account.mu.Lock()
+	account.tasks = account.tasks[:1]
+	account.completions = []map[string]interface{}{
+		{"id": "k2", "task_id": "t2", "project_id": "p2", "content": "Ship", "completed_at": "2024-01-10T12:40:00.000000Z"},
+		{"id": "k3", "task_id": "t1", "project_id": "p1", "content": "Write report", "completed_at": "2024-01-08T09:00:00.000000Z"},
+	}
+	account.labels[1]["name"] = "asap"
+	commentRequests := account.requests["/comments"]
+	account.mu.Unlock()
+
+	if _, err := mirror.Sync(context.Background()); err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+
+	if account.since[1] != "2024-1-10T12:30" {
+		t.Errorf("Unexpected since %q", account.since[1])
+	}
+	if account.requests["/comments"] != commentRequests {
+		t.Errorf("Comments of unchanged tasks were fetched again")
+	}
+	for q, expected := range map[string][][]string{
+		`SELECT id, is_completed, completed_at FROM tasks ORDER BY id`: {
+			{"t1", "0", "NULL"}, {"t2", "1", "2024-01-10T12:40:00.000000Z"}},
+		`SELECT name FROM labels ORDER BY name`:                       {{"asap"}, {"work"}},
+		`SELECT id FROM completions ORDER BY id`:                      {{"k1"}, {"k2"}, {"k3"}},
+		`SELECT id FROM comments`:                                     {{"c1"}},
+		`SELECT task_id, label FROM task_labels WHERE task_id = 't3'`: nil,
+		`SELECT l.label, strftime('%Y-%W', c.completed_at) AS week, count(*)
+		FROM completions c JOIN task_labels l ON l.task_id = c.task_id
+		GROUP BY l.label, week ORDER BY week, l.label`: {
+			{"work", "2024-01", "1"}, {"urgent", "2024-02", "1"}, {"work", "2024-02", "2"}},
+	} {
+		if actual := query(t, mirror, q); !reflect.DeepEqual(actual, expected) {
+			t.Errorf("%s: got %v, expected %v", q, actual, expected)
+		}
+	}
+}
+
+func TestSyncFailureWritesNothing(t *testing.T) {
+	account := newFakeAccount()
+	mirror := newTestMirror(t, account)
+	account.labels = nil
+	account.tasks = append(account.tasks, map[string]interface{}{"id": "t4", "project_id": "p1", "content": "x", "comment_count": 1})
+	account.comments["task_id=t4"] = nil
+
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/labels" {
+			http.Error(rw, "bad request", http.StatusBadRequest)
+			return
+		}
+		account.ServeHTTP(rw, r)
+	}))
+	defer server.Close()
+	mirror.api = todoist.New("testing-token",
+		todoist.OptionAPIURL(server.URL+"/"),
+		todoist.OptionSyncAPIURL(server.URL+"/sync/"))
+
+	if _, err := mirror.Sync(context.Background()); err == nil {
+		t.Fatal("Expected an error")
+	}
+	if rows := query(t, mirror, `SELECT id FROM tasks`); len(rows) != 0 {
+		t.Errorf("Unexpected tasks %v", rows)
+	}
+	if last, err := mirror.LastSync(context.Background()); err != nil || !last.IsZero() {
+		t.Errorf("Unexpected last sync %s, %v", last, err)
+	}
+}
@@ -0,0 +1,250 @@
+// Package todoisttext converts projects to and from plain text formats:
+// GitHub-flavored Markdown checklists and todo.txt.
+//
+// Both formats go through a Document, which Load reads from a project and
+// Push writes to one:
+//
+//	doc, err := todoisttext.Load(ctx, api, projectId)
+//	err = todoisttext.WriteMarkdown(os.Stdout, doc)
+//
+//	doc, err := todoisttext.ParseMarkdown(file)
+//	err = todoisttext.Push(ctx, api, projectId, doc)
+package todoisttext
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"sort"
+	"time"
+
+	"github.com/volyanyk/todoist"
+)
+
+// Document is a project with its sections, task tree and comments.
+type Document struct {
+	Name     string
+	Tasks    []*Item // the tasks outside of sections
+	Sections []*Section
+}
+
+type Section struct {
+	Name  string
+	Tasks []*Item
+}
+
+// Item is a task and its subtasks.
+type Item struct {
+	Content     string
+	Description string
+	Completed   bool
+	Priority    todoist.Priority // 0 stands for PriorityNormal
+	Labels      []string
+	// Due is a date (2024-01-08), an RFC 3339 date and time in UTC, a
+	// floating date and time (2024-01-08T09:00:00) or a due string, e.g.
+	// "every monday".
+	Due      string
+	Comments []string
+	Children []*Item
+}
+
+// Load reads the active tasks of a project, their sections and comments.
+// Sections and tasks keep the order of the project. Comments take one
+// GetAllComments request for every task that has any.
+func Load(ctx context.Context, api *todoist.Client, projectId string) (*Document, error) {
+	project, err := api.GetProjectByIdContext(projectId, ctx)
+	if err != nil {
+		return nil, err
+	}
+	sections, err := api.GetSectionsByProjectIdContext(projectId, ctx)
+	if err != nil {
+		return nil, err
+	}
+	tasks, err := api.GetActiveTasksContext(todoist.GetActiveTasksRequest{ProjectId: projectId}, ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	sort.SliceStable(*sections, func(i, j int) bool { return order((*sections)[i].Order) < order((*sections)[j].Order) })
+	sort.SliceStable(*tasks, func(i, j int) bool { return (*tasks)[i].Order < (*tasks)[j].Order })
+
+	doc := &Document{Name: project.Name}
+	bySection := map[string]*Section{}
+	for _, s := range *sections {
+		section := &Section{Name: s.Name}
+		bySection[s.ID] = section
+		doc.Sections = append(doc.Sections, section)
+	}
+
+	items := map[string]*Item{}
+	for _, task := range *tasks {
+		item, err := newItem(ctx, api, task)
+		if err != nil {
+			return nil, err
+		}
+		items[task.Id] = item
+	}
+	for _, task := range *tasks {
+		item := items[task.Id]
+		if task.ParentId != nil {
+			if parent, ok := items[*task.ParentId]; ok {
+				parent.Children = append(parent.Children, item)
+				continue
+			}
+		}
+		if task.SectionId != nil {
+			if section, ok := bySection[*task.SectionId]; ok {
+				section.Tasks = append(section.Tasks, item)
+				continue
+			}
+		}
+		doc.Tasks = append(doc.Tasks, item)
+	}
+	return doc, nil
+}
+
+func newItem(ctx context.Context, api *todoist.Client, task todoist.Task) (*Item, error) {
+	item := &Item{
+		Content:     task.Content,
+		Description: task.Description,
+		Completed:   task.IsCompleted,
+		Labels:      task.Labels,
+	}
+	if task.Priority != todoist.PriorityNormal {
+		item.Priority = task.Priority
+	}
+	if task.Due != nil {
+		switch {
+		case task.Due.IsRecurring:
+			item.Due = task.Due.String
+		case task.Due.Datetime != "":
+			item.Due = task.Due.Datetime
+		default:
+			item.Due = task.Due.Date
+		}
+	}
+	if task.CommentCount > 0 {
+		comments, err := api.GetAllCommentsContext("", task.Id, ctx)
+		if err != nil {
+			return nil, err
+		}
+		for _, comment := range *comments {
+			item.Comments = append(item.Comments, comment.Content)
+		}
+	}
+	return item, nil
+}
+
+func order(o *int) int {
+	if o == nil {
+		return 0
+	}
+	return *o
+}
+
+// Push adds the sections, tasks and comments of a document to a project.
+// Sections are matched by name with the existing ones and only added when
+// missing; tasks are always added. Completed items are added and closed,
+// without a recurring due date: closing a recurring task would move it to its
+// next occurrence instead of completing it.
+func Push(ctx context.Context, api *todoist.Client, projectId string, doc *Document) error {
+	existing, err := api.GetSectionsByProjectIdContext(projectId, ctx)
+	if err != nil {
+		return err
+	}
+	sectionIds := map[string]string{}
+	for _, s := range *existing {
+		sectionIds[s.Name] = s.ID
+	}
+
+	p := &pusher{api: api, projectId: projectId}
+	if err := p.items(ctx, doc.Tasks, nil, nil); err != nil {
+		return err
+	}
+	for _, section := range doc.Sections {
+		id, ok := sectionIds[section.Name]
+		if !ok {
+			added, err := api.AddSectionContext(&todoist.SectionParameters{ProjectId: projectId, Name: section.Name}, ctx)
+			if err != nil {
+				return fmt.Errorf("adding section %q: %w", section.Name, err)
+			}
+			id = added.ID
+			sectionIds[section.Name] = id
+		}
+		if err := p.items(ctx, section.Tasks, &id, nil); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+type pusher struct {
+	api       *todoist.Client
+	projectId string
+}
+
+func (p *pusher) items(ctx context.Context, items []*Item, sectionId *string, parentId *string) error {
+	for _, item := range items {
+		request := todoist.AddTaskRequest{
+			Content:     item.Content,
+			Description: item.Description,
+			ProjectId:   p.projectId,
+			SectionId:   sectionId,
+			ParentId:    parentId,
+			Labels:      item.Labels,
+		}
+		if item.Priority != 0 {
+			priority := item.Priority
+			request.Priority = &priority
+		}
+		if !item.Completed || !recurringDue.MatchString(item.Due) {
+			setDue(&request, item.Due)
+		}
+
+		task, err := p.api.AddTaskContext(request, ctx)
+		if err != nil {
+			return fmt.Errorf("adding %q: %w", item.Content, err)
+		}
+		for _, comment := range item.Comments {
+			if _, err := p.api.AddCommentContext(&todoist.NewCommentParameters{TaskId: task.Id, Content: comment}, ctx); err != nil {
+				return fmt.Errorf("commenting %q: %w", item.Content, err)
+			}
+		}
+		if err := p.items(ctx, item.Children, sectionId, &task.Id); err != nil {
+			return err
+		}
+		// Closing a task closes its subtasks, so it comes last.
+		if item.Completed {
+			if _, err := p.api.CloseTaskContext(task.Id, ctx); err != nil {
+				return fmt.Errorf("closing %q: %w", item.Content, err)
+			}
+		}
+	}
+	return nil
+}
+
+var floatingDatetime = regexp.MustCompile(`^(\d{4}-\d{2}-\d{2})T(\d{2}:\d{2})(:\d{2})?$`)
+
+// recurringDue matches the due strings of recurring tasks, e.g. "every
+// monday" or "ev 2 weeks".
+var recurringDue = regexp.MustCompile(`(?i)\b(every|ev|daily|weekly|monthly|yearly)\b`)
+
+// setDue sets the due field of request matching the form of due.
+func setDue(request *todoist.AddTaskRequest, due string) {
+	if due == "" {
+		return
+	}
+	if _, err := time.Parse("2006-01-02", due); err == nil {
+		request.DueDate = due
+		return
+	}
+	if _, err := time.Parse(time.RFC3339, due); err == nil {
+		request.DueDatetime = due
+		return
+	}
+	if match := floatingDatetime.FindStringSubmatch(due); match != nil {
+		due = match[1] + " at " + match[2]
+	}
+	request.DueString = due
+	request.DueLang = "en"
+}
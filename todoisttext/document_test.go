@@ -0,0 +1,155 @@
+package todoisttext
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/volyanyk/todoist"
+)
+
+func newTestClient(t *testing.T, handler http.HandlerFunc) *todoist.Client {
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+	return todoist.New("testing-token", todoist.OptionAPIURL(server.URL+"/"))
+}
+
+func TestLoad(t *testing.T) {
+	api := newTestClient(t, func(rw http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/projects/p1":
+			_, _ = rw.Write([]byte(`{"id":"p1","name":"Release 2.0"}`))
+		case "/sections":
+			_, _ = rw.Write([]byte(`[{"id":"s2","name":"Empty","order":2},{"id":"s1","name":"Publishing","order":1}]`))
+		case "/tasks":
+			_, _ = rw.Write([]byte(`[
+				{"id":"t5","content":"Tag (finally)","section_id":"s1","priority":2,"order":1,
+					"due":{"date":"2024-01-09","datetime":"2024-01-09T14:30:00Z"}},
+				{"id":"t3","content":"Update CI","parent_id":"t1","priority":1,"order":2,
+					"due":{"date":"2024-01-08","string":"every monday","is_recurring":true}},
+				{"id":"t1","content":"Freeze the branch","priority":4,"order":1,"comment_count":2,
+					"labels":["ops","release"],"due":{"date":"2024-01-08","string":"Jan 8"},
+					"description":"Only fixes from now on.\n\n- [ ] is not a subtask\n> nor a comment"},
+				{"id":"t2","content":"Protect the branch","parent_id":"t1","priority":1,"order":1,"is_completed":true},
+				{"id":"t4","content":"Bump runners","parent_id":"t3","priority":1,"order":1}
+			]`))
+		case "/comments":
+			if r.URL.Query().Get("task_id") != "t1" {
+				t.Errorf("Unexpected comments request %s", r.URL)
+			}
+			_, _ = rw.Write([]byte(`[{"id":"c1","content":"Announced in chat."},{"id":"c2","content":"Two lines\nof comment"}]`))
+		default:
+			http.NotFound(rw, r)
+		}
+	})
+
+	doc, err := Load(context.Background(), api, "p1")
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	if !reflect.DeepEqual(doc, getTestDocument()) {
+		var b strings.Builder
+		_ = WriteMarkdown(&b, doc)
+		t.Errorf("Unexpected document:\n%s", b.String())
+	}
+}
+
+func TestPush(t *testing.T) {
+	var calls []string
+	ids := 0
+	api := newTestClient(t, func(rw http.ResponseWriter, r *http.Request) {
+		var body map[string]interface{}
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/sections":
+			_, _ = rw.Write([]byte(`[{"id":"s1","project_id":"p1","name":"Publishing"}]`))
+		case r.Method == http.MethodPost && r.URL.Path == "/sections":
+			calls = append(calls, fmt.Sprintf("section %s", body["name"]))
+			_, _ = rw.Write([]byte(`{"id":"s2","project_id":"p1","name":"Empty"}`))
+		case r.Method == http.MethodPost && r.URL.Path == "/tasks":
+			ids++
+			due := fmt.Sprint(body["due_date"], body["due_datetime"], body["due_string"])
+			calls = append(calls, fmt.Sprintf("task %s parent=%v section=%v priority=%v due=%s",
+				body["content"], body["parent_id"], body["section_id"], body["priority"], due))
+			_, _ = fmt.Fprintf(rw, `{"id":"t%d","content":%q}`, ids, body["content"])
+		case r.Method == http.MethodPost && r.URL.Path == "/comments":
+			calls = append(calls, fmt.Sprintf("comment %s %q", body["task_id"], body["content"]))
+			_, _ = rw.Write([]byte(`{"id":"c1"}`))
+		case r.Method == http.MethodPost && strings.HasSuffix(r.URL.Path, "/close"):
+			calls = append(calls, "close "+r.URL.Path)
+			rw.WriteHeader(http.StatusNoContent)
+		default:
+			http.NotFound(rw, r)
+		}
+	})
+
+	if err := Push(context.Background(), api, "p1", getTestDocument()); err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	expected := []string{
+		"task Freeze the branch parent=<nil> section=<nil> priority=4 due=2024-01-08",
+		`comment t1 "Announced in chat."`,
+		`comment t1 "Two lines\nof comment"`,
+		"task Protect the branch parent=t1 section=<nil> priority=<nil> due=",
+		"close /tasks/t2/close",
+		"task Update CI parent=t1 section=<nil> priority=<nil> due=every monday",
+		"task Bump runners parent=t3 section=<nil> priority=<nil> due=",
+		"task Tag (finally) parent=<nil> section=s1 priority=2 due=2024-01-09T14:30:00Z",
+		"section Empty",
+	}
+	if !reflect.DeepEqual(calls, expected) {
+		t.Errorf("Unexpected calls:\n%s", strings.Join(calls, "\n"))
+	}
+}
+
+func TestPushCompletedRecurring(t *testing.T) {
+	var due []interface{}
+	api := newTestClient(t, func(rw http.ResponseWriter, r *http.Request) {
+		var body map[string]interface{}
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/sections":
+			_, _ = rw.Write([]byte(`[]`))
+		case r.Method == http.MethodPost && r.URL.Path == "/tasks":
+			due = append(due, body["due_string"])
+			_, _ = fmt.Fprintf(rw, `{"id":"t%d"}`, len(due))
+		case r.Method == http.MethodPost && strings.HasSuffix(r.URL.Path, "/close"):
+			rw.WriteHeader(http.StatusNoContent)
+		default:
+			http.NotFound(rw, r)
+		}
+	})
+
+	doc := &Document{Tasks: []*Item{
+		{Content: "Water plants", Due: "every monday", Completed: true},
+		{Content: "Call mom", Due: "tomorrow", Completed: true},
+		{Content: "Pay rent", Due: "every month"},
+	}}
+	if err := Push(context.Background(), api, "p1", doc); err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	expected := []interface{}{"", "tomorrow", "every month"}
+	if !reflect.DeepEqual(due, expected) {
+		t.Errorf("Unexpected due strings %q", due)
+	}
+}
+
+func TestSetDue(t *testing.T) {
+	for due, expected := range map[string]todoist.AddTaskRequest{
+		"2024-01-08":           {DueDate: "2024-01-08"},
+		"2024-01-08T09:00:00Z": {DueDatetime: "2024-01-08T09:00:00Z"},
+		"2024-01-08T09:00:00":  {DueString: "2024-01-08 at 09:00", DueLang: "en"},
+		"every monday":         {DueString: "every monday", DueLang: "en"},
+	} {
+		var request todoist.AddTaskRequest
+		setDue(&request, due)
+		if !reflect.DeepEqual(request, expected) {
+			t.Errorf("setDue(%q) = %+v", due, request)
+		}
+	}
+}
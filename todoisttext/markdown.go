@@ -0,0 +1,208 @@
+package todoisttext
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"regexp"
+	"strings"
+
+	"github.com/volyanyk/todoist"
+)
+
+// WriteMarkdown renders a document as a GitHub-flavored Markdown checklist:
+//
+//	# Release
+//
+//	- [ ] Tag the release (p1) (due: 2024-01-08) (labels: ops, release)
+//	  Use the release branch.
+//	  > Remember the changelog.
+//	  - [ ] Push the tag
+//
+//	## After the release
+//
+//	- [x] Announce it
+//
+// The project is the title and sections are second level headings. Each task
+// is a checkbox, followed by its priority unless p4, its due date and its
+// labels; subtasks are nested below. The description follows on indented
+// lines, and every comment is a block quote.
+func WriteMarkdown(w io.Writer, doc *Document) error {
+	b := bufio.NewWriter(w)
+	fmt.Fprintf(b, "# %s\n", doc.Name)
+	if len(doc.Tasks) > 0 {
+		b.WriteString("\n")
+		writeMarkdownItems(b, doc.Tasks, "")
+	}
+	for _, section := range doc.Sections {
+		fmt.Fprintf(b, "\n## %s\n", section.Name)
+		if len(section.Tasks) > 0 {
+			b.WriteString("\n")
+			writeMarkdownItems(b, section.Tasks, "")
+		}
+	}
+	return b.Flush()
+}
+
+func writeMarkdownItems(b *bufio.Writer, items []*Item, indent string) {
+	for _, item := range items {
+		check := " "
+		if item.Completed {
+			check = "x"
+		}
+		fmt.Fprintf(b, "%s- [%s] %s%s\n", indent, check, item.Content, markdownMeta(item))
+
+		inner := indent + "  "
+		if item.Description != "" {
+			for _, line := range strings.Split(item.Description, "\n") {
+				if line == "" {
+					b.WriteString("\n")
+					continue
+				}
+				if markdownStructure.MatchString(line) {
+					line = `\` + line
+				}
+				b.WriteString(inner + line + "\n")
+			}
+		}
+		for i, comment := range item.Comments {
+			if i > 0 || item.Description != "" {
+				b.WriteString("\n")
+			}
+			for _, line := range strings.Split(comment, "\n") {
+				b.WriteString(strings.TrimRight(inner+"> "+line, " ") + "\n")
+			}
+		}
+		if len(item.Comments) > 0 && len(item.Children) > 0 {
+			b.WriteString("\n")
+		}
+		writeMarkdownItems(b, item.Children, inner)
+	}
+}
+
+func markdownMeta(item *Item) string {
+	var meta strings.Builder
+	if item.Priority > todoist.PriorityNormal {
+		fmt.Fprintf(&meta, " (%s)", item.Priority)
+	}
+	if item.Due != "" {
+		fmt.Fprintf(&meta, " (due: %s)", item.Due)
+	}
+	if len(item.Labels) > 0 {
+		fmt.Fprintf(&meta, " (labels: %s)", strings.Join(item.Labels, ", "))
+	}
+	return meta.String()
+}
+
+var (
+	markdownItem = regexp.MustCompile(`^(\s*)[-*+] \[([ xX])\] ?(.*)$`)
+	// markdownStructure matches description lines that would be read as an
+	// item, a comment or a heading, and are escaped with a backslash.
+	markdownStructure = regexp.MustCompile(`^(\s*[-*+] \[[ xX]\]|\s*>|#|\\)`)
+	markdownMetaGroup = regexp.MustCompile(`\s*\((p[1-4]|due: [^()]*|labels: [^()]*)\)$`)
+)
+
+// ParseMarkdown reads a checklist written by WriteMarkdown. Lists may use
+// any indentation, any of the bullets -, * and +, and [X] for completed
+// items. Lines that are not part of an item, such as paragraphs below the
+// headings, are ignored.
+func ParseMarkdown(r io.Reader) (*Document, error) {
+	doc := &Document{}
+	tasks := &doc.Tasks
+
+	type level struct {
+		indent int
+		item   *Item
+	}
+	var stack []level
+	var last *Item
+	inComment := false
+	blanks := 0
+
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		line := strings.TrimRight(scanner.Text(), " \t\r")
+		trimmed := strings.TrimLeft(line, " \t")
+
+		switch {
+		case line == "":
+			blanks++
+			inComment = false
+			continue
+		case strings.HasPrefix(line, "# ") && doc.Name == "":
+			doc.Name = strings.TrimSpace(line[2:])
+			stack, last = nil, nil
+		case strings.HasPrefix(line, "## "):
+			section := &Section{Name: strings.TrimSpace(line[3:])}
+			doc.Sections = append(doc.Sections, section)
+			tasks = &section.Tasks
+			stack, last = nil, nil
+		case markdownItem.MatchString(line):
+			match := markdownItem.FindStringSubmatch(line)
+			indent := len(strings.ReplaceAll(match[1], "\t", "    "))
+			item := parseMarkdownItem(match[3])
+			item.Completed = match[2] != " "
+
+			for len(stack) > 0 && stack[len(stack)-1].indent >= indent {
+				stack = stack[:len(stack)-1]
+			}
+			if len(stack) == 0 {
+				*tasks = append(*tasks, item)
+			} else {
+				parent := stack[len(stack)-1].item
+				parent.Children = append(parent.Children, item)
+			}
+			stack = append(stack, level{indent: indent, item: item})
+			last = item
+		case last == nil:
+		case strings.HasPrefix(trimmed, ">"):
+			text := strings.TrimPrefix(strings.TrimPrefix(trimmed, ">"), " ")
+			if inComment {
+				last.Comments[len(last.Comments)-1] += "\n" + text
+			} else {
+				last.Comments = append(last.Comments, text)
+			}
+			inComment = true
+		default:
+			text := strings.TrimPrefix(trimmed, `\`)
+			if last.Description != "" {
+				text = strings.Repeat("\n", blanks+1) + text
+			}
+			last.Description += text
+		}
+		blanks = 0
+	}
+	return doc, scanner.Err()
+}
+
+// parseMarkdownItem splits the text of an item into its content and the
+// metadata groups at its end.
+func parseMarkdownItem(text string) *Item {
+	item := &Item{}
+	for {
+		match := markdownMetaGroup.FindStringSubmatchIndex(text)
+		if match == nil {
+			break
+		}
+		group := text[match[2]:match[3]]
+		text = text[:match[0]]
+		switch {
+		case strings.HasPrefix(group, "due: "):
+			item.Due = strings.TrimPrefix(group, "due: ")
+		case strings.HasPrefix(group, "labels: "):
+			for _, label := range strings.Split(strings.TrimPrefix(group, "labels: "), ",") {
+				if label = strings.TrimSpace(label); label != "" {
+					item.Labels = append(item.Labels, label)
+				}
+			}
+		default:
+			priority, _ := todoist.PriorityFromUI(group)
+			if priority > todoist.PriorityNormal {
+				item.Priority = priority
+			}
+		}
+	}
+	item.Content = strings.TrimSpace(text)
+	return item
+}
@@ -0,0 +1,100 @@
+package todoisttext
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/volyanyk/todoist"
+)
+
+func getTestDocument() *Document {
+	return &Document{
+		Name: "Release 2.0",
+		Tasks: []*Item{
+			{
+				Content:     "Freeze the branch",
+				Priority:    todoist.PriorityUrgent,
+				Due:         "2024-01-08",
+				Labels:      []string{"ops", "release"},
+				Description: "Only fixes from now on.\n\n- [ ] is not a subtask\n> nor a comment",
+				Comments:    []string{"Announced in chat.", "Two lines\nof comment"},
+				Children: []*Item{
+					{Content: "Protect the branch", Completed: true},
+					{Content: "Update CI", Due: "every monday", Children: []*Item{{Content: "Bump runners"}}},
+				},
+			},
+		},
+		Sections: []*Section{
+			{Name: "Publishing", Tasks: []*Item{
+				{Content: "Tag (finally)", Priority: todoist.PriorityMedium, Due: "2024-01-09T14:30:00Z"},
+			}},
+			{Name: "Empty"},
+		},
+	}
+}
+
+const testMarkdown = `# Release 2.0
+
+- [ ] Freeze the branch (p1) (due: 2024-01-08) (labels: ops, release)
+  Only fixes from now on.
+
+  \- [ ] is not a subtask
+  \> nor a comment
+
+  > Announced in chat.
+
+  > Two lines
+  > of comment
+
+  - [x] Protect the branch
+  - [ ] Update CI (due: every monday)
+    - [ ] Bump runners
+
+## Publishing
+
+- [ ] Tag (finally) (p3) (due: 2024-01-09T14:30:00Z)
+
+## Empty
+`
+
+func TestWriteMarkdown(t *testing.T) {
+	var b strings.Builder
+	if err := WriteMarkdown(&b, getTestDocument()); err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	if b.String() != testMarkdown {
+		t.Errorf("Unexpected Markdown:\n%s", b.String())
+	}
+}
+
+func TestParseMarkdown(t *testing.T) {
+	doc, err := ParseMarkdown(strings.NewReader(testMarkdown))
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	if !reflect.DeepEqual(doc, getTestDocument()) {
+		var b strings.Builder
+		_ = WriteMarkdown(&b, doc)
+		t.Errorf("Unexpected document:\n%s", b.String())
+	}
+}
+
+func TestParseMarkdownChecklist(t *testing.T) {
+	checklist := "# Checklist\n\nSome intro text.\n\n* [X] Done\n* [ ] Open\n\t* [ ] Nested with a tab\n+ [ ] Last (p2)\n"
+	doc, err := ParseMarkdown(strings.NewReader(checklist))
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	expected := &Document{
+		Name: "Checklist",
+		Tasks: []*Item{
+			{Content: "Done", Completed: true},
+			{Content: "Open", Children: []*Item{{Content: "Nested with a tab"}}},
+			{Content: "Last", Priority: todoist.PriorityHigh},
+		},
+	}
+	if !reflect.DeepEqual(doc, expected) {
+		t.Errorf("Unexpected document %+v", doc)
+	}
+}
@@ -0,0 +1,233 @@
+package todoisttext
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/volyanyk/todoist"
+)
+
+// todo.txt priorities of p1 to p3.
+var todoTxtPriorities = map[todoist.Priority]string{
+	todoist.PriorityUrgent: "A",
+	todoist.PriorityHigh:   "B",
+	todoist.PriorityMedium: "C",
+}
+
+// WriteTodoTxt renders a document in the todo.txt format, one task per line:
+//
+//	(A) Tag the release +Release @ops section:After_the_release due:2024-01-08 id:1
+//	Push the tag +Release parent:1
+//	x Announce it +Release due_string:every_monday
+//
+// p1 to p3 become the priorities A to C, the project a +project and labels
+// @contexts. The section, the due date, or the due string of recurring
+// tasks, and the parent task are key:value tags; tasks having subtasks get
+// an id: tag. Spaces in the project, labels, sections and due strings are
+// written as underscores, and underscores and percent signs in them as %5F
+// and %25. Words of the content that would be read back as metadata, such as
+// @home, a leading x or section:x, are escaped with a backslash. The format
+// has no place for descriptions and comments, which are left out.
+func WriteTodoTxt(w io.Writer, doc *Document) error {
+	t := &todoTxtWriter{w: bufio.NewWriter(w), project: todoTxtWord(doc.Name)}
+	t.items(doc.Tasks, "", "")
+	for _, section := range doc.Sections {
+		t.items(section.Tasks, section.Name, "")
+	}
+	return t.w.Flush()
+}
+
+type todoTxtWriter struct {
+	w       *bufio.Writer
+	project string
+	ids     int
+}
+
+func (t *todoTxtWriter) items(items []*Item, section string, parent string) {
+	for _, item := range items {
+		var fields []string
+		if item.Completed {
+			fields = append(fields, "x")
+		}
+		if priority, ok := todoTxtPriorities[item.Priority]; ok {
+			fields = append(fields, "("+priority+")")
+		}
+		fields = append(fields, todoTxtContent(item.Content))
+		if t.project != "" {
+			fields = append(fields, "+"+t.project)
+		}
+		for _, label := range item.Labels {
+			fields = append(fields, "@"+todoTxtWord(label))
+		}
+		if section != "" {
+			fields = append(fields, "section:"+todoTxtWord(section))
+		}
+		if item.Due != "" {
+			if todoTxtDate.MatchString(item.Due) {
+				fields = append(fields, "due:"+item.Due)
+			} else {
+				fields = append(fields, "due_string:"+todoTxtWord(item.Due))
+			}
+		}
+		id := ""
+		if len(item.Children) > 0 {
+			t.ids++
+			id = strconv.Itoa(t.ids)
+			fields = append(fields, "id:"+id)
+		}
+		if parent != "" {
+			fields = append(fields, "parent:"+parent)
+		}
+		fmt.Fprintln(t.w, strings.Join(fields, " "))
+		t.items(item.Children, section, id)
+	}
+}
+
+// todoTxtDate matches the due values written as due: tags, dates and date
+// times.
+var todoTxtDate = regexp.MustCompile(`^\d{4}-\d{2}-\d{2}(T\d{2}:\d{2}(:\d{2})?Z?)?$`)
+
+var todoTxtWordEscaper = strings.NewReplacer(
+	"%", "%25", "_", "%5F", " ", "_", "\t", "%09", "\n", "%0A", "\r", "%0D",
+)
+
+// todoTxtWord writes a name as a single word.
+func todoTxtWord(s string) string {
+	return todoTxtWordEscaper.Replace(s)
+}
+
+func fromTodoTxtWord(s string) string {
+	s = strings.ReplaceAll(s, "_", " ")
+	if unescaped, err := url.PathUnescape(s); err == nil {
+		return unescaped
+	}
+	return s
+}
+
+// todoTxtTags are the key:value tags read by ParseTodoTxt.
+var todoTxtTags = map[string]bool{"section": true, "due": true, "due_string": true, "id": true, "parent": true}
+
+// todoTxtContent escapes the words of a task's content that ParseTodoTxt
+// would read as metadata with a backslash.
+func todoTxtContent(content string) string {
+	words := strings.Fields(content)
+	for i, word := range words {
+		key, value, tag := strings.Cut(word, ":")
+		switch {
+		case strings.HasPrefix(word, `\`),
+			len(word) > 1 && (word[0] == '+' || word[0] == '@'),
+			tag && value != "" && todoTxtTags[key],
+			i == 0 && (word == "x" || todoTxtPriority(word) || todoTxtCreated(word)):
+			words[i] = `\` + word
+		}
+	}
+	return strings.Join(words, " ")
+}
+
+func todoTxtPriority(word string) bool {
+	return len(word) == 3 && word[0] == '(' && word[2] == ')'
+}
+
+func todoTxtCreated(word string) bool {
+	return len(word) == len("2006-01-02") && todoTxtDate.MatchString(word)
+}
+
+// ParseTodoTxt reads tasks in the todo.txt format, see WriteTodoTxt. The
+// document is named after the first +project, a completion and creation date
+// are skipped, and other key:value tags are kept in the content. A leading
+// backslash is removed from words, which are then kept in the content as
+// they are. Subtasks must follow the task with their parent: id.
+func ParseTodoTxt(r io.Reader) (*Document, error) {
+	doc := &Document{}
+	sections := map[string]*Section{}
+	parents := map[string]*Item{}
+
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	for n := 1; scanner.Scan(); n++ {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) == 0 {
+			continue
+		}
+
+		item := &Item{}
+		if fields[0] == "x" {
+			item.Completed = true
+			fields = fields[1:]
+		}
+		if len(fields) > 0 && todoTxtPriority(fields[0]) {
+			switch fields[0][1] {
+			case 'A':
+				item.Priority = todoist.PriorityUrgent
+			case 'B':
+				item.Priority = todoist.PriorityHigh
+			case 'C':
+				item.Priority = todoist.PriorityMedium
+			}
+			fields = fields[1:]
+		}
+		for len(fields) > 0 && todoTxtCreated(fields[0]) {
+			fields = fields[1:]
+		}
+
+		var content []string
+		section, id, parent := "", "", ""
+		for _, field := range fields {
+			key, value, _ := strings.Cut(field, ":")
+			switch {
+			case strings.HasPrefix(field, `\`):
+				content = append(content, field[1:])
+			case len(field) > 1 && field[0] == '+':
+				if doc.Name == "" {
+					doc.Name = fromTodoTxtWord(field[1:])
+				}
+			case len(field) > 1 && field[0] == '@':
+				item.Labels = append(item.Labels, fromTodoTxtWord(field[1:]))
+			case key == "section" && value != "":
+				section = fromTodoTxtWord(value)
+			case key == "due" && value != "":
+				item.Due = value
+			case key == "due_string" && value != "":
+				item.Due = fromTodoTxtWord(value)
+			case key == "id" && value != "":
+				id = value
+			case key == "parent" && value != "":
+				parent = value
+			default:
+				content = append(content, field)
+			}
+		}
+		item.Content = strings.Join(content, " ")
+		if item.Content == "" {
+			return nil, fmt.Errorf("line %d: missing task", n)
+		}
+		if id != "" {
+			parents[id] = item
+		}
+
+		switch {
+		case parent != "":
+			p, ok := parents[parent]
+			if !ok {
+				return nil, fmt.Errorf("line %d: unknown parent %s", n, parent)
+			}
+			p.Children = append(p.Children, item)
+		case section != "":
+			s, ok := sections[section]
+			if !ok {
+				s = &Section{Name: section}
+				sections[section] = s
+				doc.Sections = append(doc.Sections, s)
+			}
+			s.Tasks = append(s.Tasks, item)
+		default:
+			doc.Tasks = append(doc.Tasks, item)
+		}
+	}
+	return doc, scanner.Err()
+}
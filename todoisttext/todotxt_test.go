@@ -0,0 +1,94 @@
+package todoisttext
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/volyanyk/todoist"
+)
+
+const testTodoTxt = `(A) Freeze the branch +Release_2.0 @ops @release due:2024-01-08 id:1
+x Protect the branch +Release_2.0 parent:1
+Update CI +Release_2.0 due_string:every_monday id:2 parent:1
+Bump runners +Release_2.0 parent:2
+(C) Tag (finally) +Release_2.0 section:Publishing due:2024-01-09T14:30:00Z
+`
+
+func TestWriteTodoTxt(t *testing.T) {
+	var b strings.Builder
+	if err := WriteTodoTxt(&b, getTestDocument()); err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	if b.String() != testTodoTxt {
+		t.Errorf("Unexpected todo.txt:\n%s", b.String())
+	}
+}
+
+func TestParseTodoTxt(t *testing.T) {
+	doc, err := ParseTodoTxt(strings.NewReader(testTodoTxt))
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+
+	// todo.txt keeps neither descriptions, comments nor empty sections.
+	expected := getTestDocument()
+	expected.Tasks[0].Description = ""
+	expected.Tasks[0].Comments = nil
+	expected.Sections = expected.Sections[:1]
+	if !reflect.DeepEqual(doc, expected) {
+		var b strings.Builder
+		_ = WriteTodoTxt(&b, doc)
+		t.Errorf("Unexpected document:\n%s", b.String())
+	}
+}
+
+func TestParseTodoTxtDates(t *testing.T) {
+	doc, err := ParseTodoTxt(strings.NewReader("x 2024-01-10 2024-01-02 Pay rent https://bank.example rent:monthly @home\n(D) 2024-01-02 Later\n"))
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	expected := &Document{Tasks: []*Item{
+		{Content: "Pay rent https://bank.example rent:monthly", Completed: true, Labels: []string{"home"}},
+		{Content: "Later"},
+	}}
+	if !reflect.DeepEqual(doc, expected) {
+		t.Errorf("Unexpected document %+v", doc.Tasks)
+	}
+
+	if _, err := ParseTodoTxt(strings.NewReader("Orphan parent:7\n")); err == nil {
+		t.Error("Expected an error for an unknown parent")
+	}
+	if _, err := ParseTodoTxt(strings.NewReader("(A) @label\n")); err == nil {
+		t.Error("Expected an error for a line without task")
+	}
+}
+
+func TestTodoTxtRoundTrip(t *testing.T) {
+	doc := &Document{
+		Name: "Home_office 100%",
+		Tasks: []*Item{
+			{Content: "x marks the spot", Labels: []string{"waiting_for", "50% off"}},
+			{Content: "(A) is not a priority", Completed: true, Due: "every 2_weeks"},
+			{Content: "2024-01-02 is not a creation date"},
+			{Content: `Mail @bob about +project due:friday and \escaped words`, Priority: todoist.PriorityHigh},
+		},
+		Sections: []*Section{
+			{Name: "Next_steps: soon", Tasks: []*Item{{Content: "Read section:2 of the spec"}}},
+		},
+	}
+
+	var b strings.Builder
+	if err := WriteTodoTxt(&b, doc); err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	parsed, err := ParseTodoTxt(strings.NewReader(b.String()))
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	if !reflect.DeepEqual(parsed, doc) {
+		var written strings.Builder
+		_ = WriteTodoTxt(&written, parsed)
+		t.Errorf("Unexpected document:\n%s\nwritten as:\n%s", written.String(), b.String())
+	}
+}
@@ -0,0 +1,123 @@
+package todoist
+
+import (
+	"context"
+	"net/url"
+	"time"
+)
+
+type UserResponse struct {
+	User User `json:"user"`
+	SyncResponse
+}
+
+type User struct {
+	Id             string       `json:"id"`
+	Email          string       `json:"email"`
+	FullName       string       `json:"full_name"`
+	Lang           string       `json:"lang"`
+	TzInfo         TimezoneInfo `json:"tz_info"`
+	StartDay       int          `json:"start_day"`
+	DailyGoal      int          `json:"daily_goal"`
+	WeeklyGoal     int          `json:"weekly_goal"`
+	IsPremium      bool         `json:"is_premium"`
+	InboxProjectId string       `json:"inbox_project_id"`
+	Karma          float64      `json:"karma"`
+}
+
+type TimezoneInfo struct {
+	Timezone  string `json:"timezone"`
+	GmtString string `json:"gmt_string"`
+	Hours     int    `json:"hours"`
+	Minutes   int    `json:"minutes"`
+	IsDst     int    `json:"is_dst"`
+}
+
+type ProductivityStats struct {
+	Karma           float64     `json:"karma"`
+	KarmaTrend      string      `json:"karma_trend"`
+	KarmaLastUpdate float64     `json:"karma_last_update"`
+	CompletedCount  int         `json:"completed_count"`
+	DaysItems       []DayStats  `json:"days_items"`
+	WeekItems       []WeekStats `json:"week_items"`
+	Goals           GoalStats   `json:"goals"`
+}
+
+type DayStats struct {
+	Date           string              `json:"date"`
+	TotalCompleted int                 `json:"total_completed"`
+	Items          []ProjectCompletion `json:"items"`
+}
+
+type WeekStats struct {
+	From           string              `json:"from"`
+	To             string              `json:"to"`
+	TotalCompleted int                 `json:"total_completed"`
+	Items          []ProjectCompletion `json:"items"`
+}
+
+type ProjectCompletion struct {
+	Id        string `json:"id"`
+	Completed int    `json:"completed"`
+}
+
+type GoalStats struct {
+	DailyGoal           int    `json:"daily_goal"`
+	WeeklyGoal          int    `json:"weekly_goal"`
+	CurrentDailyStreak  Streak `json:"current_daily_streak"`
+	CurrentWeeklyStreak Streak `json:"current_weekly_streak"`
+	MaxDailyStreak      Streak `json:"max_daily_streak"`
+	MaxWeeklyStreak     Streak `json:"max_weekly_streak"`
+	IgnoreDays          []int  `json:"ignore_days"`
+	VacationMode        int    `json:"vacation_mode"`
+	KarmaDisabled       int    `json:"karma_disabled"`
+}
+
+type Streak struct {
+	Count int    `json:"count"`
+	Start string `json:"start"`
+	End   string `json:"end"`
+}
+
+// Location returns the time zone of the user, falling back to a fixed offset
+// when the zone name is unknown to the local time zone database.
+func (u User) Location() *time.Location {
+	if location, err := time.LoadLocation(u.TzInfo.Timezone); err == nil && u.TzInfo.Timezone != "" {
+		return location
+	}
+	offset := u.TzInfo.Hours*60*60 + u.TzInfo.Minutes*60
+	if u.TzInfo.Hours < 0 {
+		offset = u.TzInfo.Hours*60*60 - u.TzInfo.Minutes*60
+	}
+	return time.FixedZone(u.TzInfo.GmtString, offset)
+}
+
+func (api *Client) GetUser() (*User, error) {
+	return api.GetUserContext(context.Background())
+}
+func (api *Client) GetProductivityStats() (*ProductivityStats, error) {
+	return api.GetProductivityStatsContext(context.Background())
+}
+
+func (api *Client) GetUserContext(context context.Context) (*User, error) {
+	response := &UserResponse{}
+
+	err := api.readResources(context, []string{"user"}, response)
+	if err != nil {
+		return nil, err
+	}
+
+	return &response.User, nil
+}
+
+func (api *Client) GetProductivityStatsContext(context context.Context) (*ProductivityStats, error) {
+	response := &ProductivityStats{}
+
+	err := api.syncGet(context,
+		"completed/get_stats",
+		api.token,
+		url.Values{},
+		response)
+
+	return response, err
+}
@@ -0,0 +1,121 @@
+package todoist
+
+import (
+	"encoding/json"
+	"net/http"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestGetUser(t *testing.T) {
+	http.DefaultServeMux = new(http.ServeMux)
+	http.HandleFunc("/sync", getUser)
+	once.Do(startServer)
+	expectedUser := getTestUser()
+
+	api := New(validToken, OptionSyncAPIURL("http://"+serverAddr+"/"))
+	user, err := api.GetUser()
+	if err != nil {
+		t.Errorf("Unexpected error: %s", err)
+		return
+	}
+	if !reflect.DeepEqual(expectedUser, *user) {
+		t.Fatal(ErrIncorrectResponse)
+	}
+}
+
+func TestGetProductivityStats(t *testing.T) {
+	http.DefaultServeMux = new(http.ServeMux)
+	http.HandleFunc("/completed/get_stats", getProductivityStats)
+	once.Do(startServer)
+	expectedStats := getTestProductivityStats()
+
+	api := New(validToken, OptionSyncAPIURL("http://"+serverAddr+"/"))
+	stats, err := api.GetProductivityStats()
+	if err != nil {
+		t.Errorf("Unexpected error: %s", err)
+		return
+	}
+	if !reflect.DeepEqual(expectedStats, *stats) {
+		t.Fatal(ErrIncorrectResponse)
+	}
+}
+
+func TestDueTime(t *testing.T) {
+	user := getTestUser()
+	location := user.Location()
+
+	floating, err := Due{Date: "2024-01-02", Datetime: "2024-01-02T12:00:00"}.Time(location)
+	if err != nil || !floating.Equal(time.Date(2024, 1, 2, 11, 0, 0, 0, time.UTC)) {
+		t.Fatalf("Unexpected floating time: %s, %v", floating, err)
+	}
+	fixed, err := Due{Datetime: "2024-01-02T12:00:00.000000Z", Timezone: "Asia/Tokyo"}.Time(location)
+	if err != nil || !fixed.Equal(time.Date(2024, 1, 2, 12, 0, 0, 0, time.UTC)) {
+		t.Fatalf("Unexpected fixed time: %s, %v", fixed, err)
+	}
+	date, err := Due{Date: "2024-01-02"}.Time(location)
+	if err != nil || !date.Equal(time.Date(2024, 1, 1, 23, 0, 0, 0, time.UTC)) {
+		t.Fatalf("Unexpected date: %s, %v", date, err)
+	}
+	local, err := Due{Date: "2024-01-02"}.Time(nil)
+	if err != nil || !local.Equal(time.Date(2024, 1, 2, 0, 0, 0, 0, time.Local)) {
+		t.Fatalf("Unexpected local date: %s, %v", local, err)
+	}
+}
+
+func getTestUser() User {
+	return User{
+		Id:       "1",
+		Email:    "me@example.com",
+		FullName: "Test User",
+		TzInfo: TimezoneInfo{
+			Timezone:  "Unknown/Zone",
+			GmtString: "+01:00",
+			Hours:     1,
+		},
+		StartDay:       1,
+		DailyGoal:      5,
+		WeeklyGoal:     25,
+		IsPremium:      true,
+		InboxProjectId: "100",
+	}
+}
+
+func getTestProductivityStats() ProductivityStats {
+	return ProductivityStats{
+		Karma:          1000,
+		KarmaTrend:     "up",
+		CompletedCount: 42,
+		DaysItems: []DayStats{
+			{Date: "2024-01-02", TotalCompleted: 3, Items: []ProjectCompletion{{Id: "1", Completed: 3}}},
+		},
+		WeekItems: []WeekStats{
+			{From: "2024-01-01", To: "2024-01-07", TotalCompleted: 3, Items: []ProjectCompletion{{Id: "1", Completed: 3}}},
+		},
+		Goals: GoalStats{
+			DailyGoal:          5,
+			WeeklyGoal:         25,
+			CurrentDailyStreak: Streak{Count: 2, Start: "2024-01-01", End: "2024-01-02"},
+			MaxDailyStreak:     Streak{Count: 10, Start: "2023-05-01", End: "2023-05-10"},
+		},
+	}
+}
+
+func getUser(rw http.ResponseWriter, _ *http.Request) {
+	rw.Header().Set("Content-Type", "application/json")
+	response, _ := json.Marshal(UserResponse{User: getTestUser()})
+	_, err := rw.Write(response)
+	if err != nil {
+		return
+	}
+}
+
+func getProductivityStats(rw http.ResponseWriter, _ *http.Request) {
+	rw.Header().Set("Content-Type", "application/json")
+	response, _ := json.Marshal(getTestProductivityStats())
+	_, err := rw.Write(response)
+	if err != nil {
+		return
+	}
+}
@@ -0,0 +1,164 @@
+package todoist
+
+import (
+	"fmt"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// Length limits enforced by Todoist, in characters.
+const (
+	maxTaskContentLength     = 500
+	maxTaskDescriptionLength = 16383
+	maxNameLength            = 120
+	maxLabelNameLength       = 60
+	maxCommentLength         = 15000
+)
+
+// Defaults of new projects, restored when an update clears them.
+const (
+	defaultColor     = ColorCharcoal
+	defaultViewStyle = ViewStyleList
+)
+
+// FieldError describes a problem with a single request field, named after
+// its JSON key.
+type FieldError struct {
+	Field   string
+	Message string
+}
+
+func (e FieldError) Error() string {
+	return e.Field + ": " + e.Message
+}
+
+// ValidationError is returned, before anything is sent, for requests failing
+// their Validate method. It lists every offending field.
+type ValidationError struct {
+	Request string // e.g. "AddTaskRequest"
+	Fields  []FieldError
+}
+
+func (e *ValidationError) Error() string {
+	problems := make([]string, len(e.Fields))
+	for i, field := range e.Fields {
+		problems[i] = field.Error()
+	}
+	return fmt.Sprintf("invalid %s: %s", e.Request, strings.Join(problems, "; "))
+}
+
+// Unwrap exposes the field errors to errors.As.
+func (e *ValidationError) Unwrap() []error {
+	errs := make([]error, len(e.Fields))
+	for i, field := range e.Fields {
+		errs[i] = field
+	}
+	return errs
+}
+
+// validator collects the field errors of a request.
+type validator struct {
+	request string
+	fields  []FieldError
+}
+
+func newValidator(request string) *validator {
+	return &validator{request: request}
+}
+
+func (v *validator) add(field string, format string, args ...interface{}) {
+	v.fields = append(v.fields, FieldError{Field: field, Message: fmt.Sprintf(format, args...)})
+}
+
+func (v *validator) required(field string, value string) {
+	if strings.TrimSpace(value) == "" {
+		v.add(field, "is required")
+	}
+}
+
+func (v *validator) maxLength(field string, value string, max int) {
+	if n := utf8.RuneCountInString(value); n > max {
+		v.add(field, "is %d characters long, at most %d are allowed", n, max)
+	}
+}
+
+func (v *validator) priority(field string, priority *Priority) {
+	if priority != nil && !priority.Valid() {
+		v.add(field, "must be between 1 and 4, got %d", int(*priority))
+	}
+}
+
+func (v *validator) oneOf(field string, value string, allowed []string) {
+	if value != "" && !containsString(allowed, value) {
+		v.add(field, "must be one of %s, got %q", strings.Join(allowed, ", "), value)
+	}
+}
+
+// validEnum checks that a non-zero value is known.
+func validEnum[T interface {
+	comparable
+	enum
+}](v *validator, field string, value T) {
+	var zero T
+	if value != zero && !value.Valid() {
+		v.add(field, "unknown value %q", fmt.Sprint(value))
+	}
+}
+
+// due checks that at most one way of setting a due date is used, and the
+// format of the one that is.
+func (v *validator) due(dueString, dueDate, dueDatetime string) {
+	var set []string
+	for _, due := range []struct{ field, value string }{
+		{"due_string", dueString},
+		{"due_date", dueDate},
+		{"due_datetime", dueDatetime},
+	} {
+		if due.value != "" {
+			set = append(set, due.field)
+		}
+	}
+	if len(set) > 1 {
+		for _, field := range set[1:] {
+			v.add(field, "conflicts with %s", set[0])
+		}
+		return
+	}
+	v.date("due_date", dueDate)
+	if dueDatetime != "" {
+		if _, err := time.Parse(time.RFC3339, dueDatetime); err != nil {
+			v.add("due_datetime", "must be an RFC 3339 date and time, got %q", dueDatetime)
+		}
+	}
+}
+
+func (v *validator) date(field string, value string) {
+	if value != "" {
+		if _, err := time.Parse("2006-01-02", value); err != nil {
+			v.add(field, "must be formatted as YYYY-MM-DD, got %q", value)
+		}
+	}
+}
+
+// duration checks that a duration, if any, has a positive amount and a unit.
+func (v *validator) duration(amount int, unit DurationUnit) {
+	if amount == 0 && unit == "" {
+		return
+	}
+	if amount <= 0 {
+		v.add("duration", "must be positive, got %d", amount)
+	}
+	if unit == "" {
+		v.add("duration_unit", "is required with duration")
+	} else {
+		validEnum(v, "duration_unit", unit)
+	}
+}
+
+func (v *validator) err() error {
+	if len(v.fields) == 0 {
+		return nil
+	}
+	return &ValidationError{Request: v.request, Fields: v.fields}
+}
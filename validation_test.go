@@ -0,0 +1,91 @@
+package todoist
+
+import (
+	"errors"
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestAddTaskValidation(t *testing.T) {
+	http.DefaultServeMux = new(http.ServeMux)
+	sent := false
+	http.HandleFunc("/tasks", func(rw http.ResponseWriter, r *http.Request) {
+		sent = true
+	})
+	once.Do(startServer)
+	api := New(validToken, OptionAPIURL("http://"+serverAddr+"/"))
+
+	priority := Priority(5)
+	_, err := api.AddTask(AddTaskRequest{
+		Description: strings.Repeat("a", maxTaskDescriptionLength+1),
+		Priority:    &priority,
+		DueString:   "tomorrow",
+		DueDate:     "2023-01-01",
+	})
+
+	validationError := &ValidationError{}
+	if !errors.As(err, &validationError) {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	fields := make([]string, len(validationError.Fields))
+	for i, field := range validationError.Fields {
+		fields[i] = field.Field
+	}
+	if !reflect.DeepEqual([]string{"content", "description", "priority", "due_date"}, fields) {
+		t.Fatalf("Unexpected fields: %v", fields)
+	}
+	fieldError := FieldError{}
+	if !errors.As(err, &fieldError) || fieldError.Field != "content" {
+		t.Fatal(ErrIncorrectResponse)
+	}
+	if sent {
+		t.Fatal("Invalid request was sent")
+	}
+}
+
+func TestRequestValidate(t *testing.T) {
+	priority := PriorityUrgent
+	valid := []interface{ Validate() error }{
+		AddTaskRequest{Content: "Buy milk", Priority: &priority, DueDatetime: "2023-01-01T12:00:00Z"},
+		UpdateTaskRequest{DueDate: Set("2023-01-01")},
+		AddProjectRequest{Name: "Shopping", Color: "berry_red", ViewStyle: "board"},
+		UpdateProjectRequest{Name: Set("Shopping")},
+		LabelRequest{Color: "grey"},
+		SectionParameters{ProjectId: "1", Name: "Groceries"},
+		NewCommentParameters{TaskId: "1", Content: "Soon"},
+	}
+	for _, request := range valid {
+		if err := request.Validate(); err != nil {
+			t.Errorf("Unexpected error for %+v: %s", request, err)
+		}
+	}
+
+	invalid := []interface{ Validate() error }{
+		AddTaskRequest{Content: strings.Repeat("é", maxTaskContentLength+1)},
+		AddTaskRequest{Content: "Buy milk", DueDate: "01/01/2023"},
+		UpdateTaskRequest{DueString: Set("today"), DueDatetime: Set("2023-01-01T12:00:00Z")},
+		AddProjectRequest{},
+		AddProjectRequest{Name: "Shopping", Color: "pink"},
+		UpdateProjectRequest{ViewStyle: Set[ViewStyle]("gallery")},
+		LabelRequest{Name: strings.Repeat("a", maxLabelNameLength+1)},
+		SectionParameters{Name: "Groceries"},
+		NewCommentParameters{TaskId: "1"},
+	}
+	for _, request := range invalid {
+		if err := request.Validate(); err == nil {
+			t.Errorf("Expected an error for %+v", request)
+		}
+	}
+}
+
+func TestAddLabelRequiresName(t *testing.T) {
+	api := New(validToken, OptionAPIURL("http://"+serverAddr+"/"))
+
+	_, err := api.AddLabel(LabelRequest{Color: "grey"})
+	validationError := &ValidationError{}
+	if !errors.As(err, &validationError) || validationError.Fields[0].Field != "name" {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+}